@@ -8,6 +8,7 @@ require (
 	github.com/hashicorp/terraform-plugin-sdk v1.13.0
 	github.com/pkg/errors v0.9.1
 	github.com/sethvargo/go-password v0.1.3
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	golang.org/x/net v0.0.0-20210510120150-4163338589ed // indirect
 	golang.org/x/oauth2 v0.0.0-20210514164344-f6687ab2804c
 	google.golang.org/api v0.44.0-impersonate-preview
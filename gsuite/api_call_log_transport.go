@@ -0,0 +1,39 @@
+package gsuite
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// apiCallLogTransport logs a single line per request with its method, URL,
+// status and latency, but unlike the logging transport of the SDK never the
+// headers or bodies.
+type apiCallLogTransport struct {
+	logf func(format string, v ...interface{})
+	next http.RoundTripper
+}
+
+func newAPICallLogTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &apiCallLogTransport{
+		logf: log.Printf,
+		next: next,
+	}
+}
+
+func (t *apiCallLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+
+	if err != nil {
+		t.logf("[INFO] Google API call: %s %s failed after %s: %s", req.Method, req.URL, latency, err)
+		return nil, err
+	}
+
+	t.logf("[INFO] Google API call: %s %s %d %s", req.Method, req.URL, resp.StatusCode, latency)
+	return resp, nil
+}
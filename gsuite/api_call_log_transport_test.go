@@ -0,0 +1,47 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestAPICallLogTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":404,"message":"secret body"}}`))
+	}))
+	defer server.Close()
+
+	var lines []string
+	transport := newAPICallLogTransport(nil).(*apiCallLogTransport)
+	transport.logf = func(format string, v ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, v...))
+	}
+
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(server.URL + "/admin/directory/v1/users/jdoe@domain.ext")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(lines) != 1 {
+		t.Fatalf("expected a single log line, got %v", lines)
+	}
+	expected := regexp.MustCompile(`^\[INFO\] Google API call: GET ` + regexp.QuoteMeta(server.URL) + `/admin/directory/v1/users/jdoe@domain.ext 404 [0-9.]+m?s$`)
+	if !expected.MatchString(lines[0]) {
+		t.Fatalf("unexpected log line: %s", lines[0])
+	}
+
+	lines = nil
+	server.Close()
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatalf("expected the request to a closed server to fail")
+	}
+	if len(lines) != 1 || !regexp.MustCompile(`^\[INFO\] Google API call: GET \S+ failed after [0-9.]+[mµn]?s: `).MatchString(lines[0]) {
+		t.Fatalf("unexpected log lines: %v", lines)
+	}
+}
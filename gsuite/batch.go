@@ -0,0 +1,184 @@
+package gsuite
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+)
+
+const defaultDirectoryBatchURL = "https://www.googleapis.com/batch/admin/directory_v1"
+
+// The Admin SDK accepts at most 50 calls per batch request
+const batchMaxCalls = 50
+
+// batchCall is a single API call of a batch request, path is relative to the
+// API host, e.g. /admin/directory/v1/groups/{groupKey}/members.
+type batchCall struct {
+	method string
+	path   string
+	body   interface{}
+}
+
+// batchResult is the outcome of a single call, err holds the API error of the
+// call, like a regular call would return it.
+type batchResult struct {
+	statusCode int
+	body       []byte
+	err        error
+}
+
+func (c *Config) directoryBatchURL() string {
+	if c.batchURL != "" {
+		return c.batchURL
+	}
+	return defaultDirectoryBatchURL
+}
+
+// executeBatch sends the calls as batch requests of at most batchMaxCalls
+// calls each. The results are in the order of the calls, an error is only
+// returned when a whole batch request failed.
+func (c *Config) executeBatch(calls []batchCall) ([]batchResult, error) {
+	results := make([]batchResult, 0, len(calls))
+	for start := 0; start < len(calls); start += batchMaxCalls {
+		end := start + batchMaxCalls
+		if end > len(calls) {
+			end = len(calls)
+		}
+
+		var batchResults []batchResult
+		var err error
+		err = retry(func() error {
+			batchResults, err = c.executeBatchRequest(calls[start:end])
+			return err
+		}, c.TimeoutMinutes)
+
+		if err != nil {
+			return results, fmt.Errorf("[ERROR] Error executing batch request: %s", err)
+		}
+		results = append(results, batchResults...)
+	}
+	return results, nil
+}
+
+func (c *Config) executeBatchRequest(calls []batchCall) ([]batchResult, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for i, call := range calls {
+		var body []byte
+		if call.body != nil {
+			var err error
+			body, err = json.Marshal(call.body)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {"application/http"},
+			"Content-Id":   {fmt.Sprintf("<item-%d>", i)},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(part, "%s %s HTTP/1.1\r\n", call.method, call.path)
+		if body != nil {
+			fmt.Fprintf(part, "Content-Type: application/json\r\nContent-Length: %d\r\n", len(body))
+		}
+		fmt.Fprint(part, "\r\n")
+		part.Write(body)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.directoryBatchURL(), &buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	log.Printf("[DEBUG] Sending batch request with %d calls", len(calls))
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// A failure of the whole batch is reported like the error of a regular call
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return parseBatchResponse(resp, len(calls))
+}
+
+// parseBatchResponse maps every part of the multipart response back to its
+// call using the Content-ID, which is "response-" followed by the ID of the
+// call.
+func parseBatchResponse(resp *http.Response, count int) ([]batchResult, error) {
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch response content type: %s", err)
+	}
+
+	results := make([]batchResult, count)
+	seen := make([]bool, count)
+	mr := multipart.NewReader(resp.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		contentID := strings.Trim(part.Header.Get("Content-Id"), "<>")
+		index, err := strconv.Atoi(strings.TrimPrefix(contentID, "response-item-"))
+		if err != nil || index < 0 || index >= count {
+			return nil, fmt.Errorf("unexpected Content-ID %q in batch response", contentID)
+		}
+
+		partResp, err := http.ReadResponse(bufio.NewReader(part), nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response for batch call %d: %s", index, err)
+		}
+		body, err := ioutil.ReadAll(partResp.Body)
+		partResp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		// Let CheckResponse build the same error a regular call would return
+		partResp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		results[index] = batchResult{
+			statusCode: partResp.StatusCode,
+			body:       body,
+			err:        googleapi.CheckResponse(partResp),
+		}
+		seen[index] = true
+	}
+
+	for i := range seen {
+		if !seen[i] {
+			results[i] = batchResult{err: fmt.Errorf("no response for batch call %d", i)}
+		}
+	}
+	return results, nil
+}
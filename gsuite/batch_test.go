@@ -0,0 +1,172 @@
+package gsuite
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testBatchServer answers every call of a batch request with the status
+// returned by status, and records the calls it received.
+func testBatchServer(status func(method, path string) int) (*httptest.Server, *[]string, *int) {
+	var mu sync.Mutex
+	calls := []string{}
+	batches := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		batches++
+		mu.Unlock()
+
+		// The whole request needs to be read before writing the response
+		type call struct {
+			id   string
+			code int
+		}
+		var received []call
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			req, err := http.ReadRequest(bufio.NewReader(part))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			mu.Lock()
+			calls = append(calls, req.Method+" "+req.URL.Path)
+			mu.Unlock()
+
+			received = append(received, call{
+				id:   strings.Trim(part.Header.Get("Content-Id"), "<>"),
+				code: status(req.Method, req.URL.Path),
+			})
+		}
+
+		mw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+		for _, c := range received {
+			body := "{}"
+			if c.code >= 300 {
+				body = fmt.Sprintf(`{"error":{"code":%d,"message":"failed"}}`, c.code)
+			}
+
+			out, _ := mw.CreatePart(map[string][]string{
+				"Content-Type": {"application/http"},
+				"Content-Id":   {"<response-" + c.id + ">"},
+			})
+			fmt.Fprintf(out, "HTTP/1.1 %d %s\r\nContent-Type: application/json\r\n\r\n%s", c.code, http.StatusText(c.code), body)
+		}
+		mw.Close()
+	}))
+	return server, &calls, &batches
+}
+
+func TestExecuteBatch(t *testing.T) {
+	server, calls, batches := testBatchServer(func(method, path string) int {
+		if strings.HasSuffix(path, "/missing@domain.ext") {
+			return http.StatusNotFound
+		}
+		return http.StatusNoContent
+	})
+	defer server.Close()
+
+	config := &Config{client: server.Client(), batchURL: server.URL, TimeoutMinutes: 1}
+
+	var batchCalls []batchCall
+	for i := 0; i < 60; i++ {
+		batchCalls = append(batchCalls, batchCall{
+			method: http.MethodDelete,
+			path:   fmt.Sprintf("/admin/directory/v1/groups/g@domain.ext/members/user%d@domain.ext", i),
+		})
+	}
+	batchCalls = append(batchCalls, batchCall{
+		method: http.MethodDelete,
+		path:   "/admin/directory/v1/groups/g@domain.ext/members/missing@domain.ext",
+	})
+
+	results, err := config.executeBatch(batchCalls)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *batches != 2 {
+		t.Errorf("expected 2 batch requests for %d calls, got %d", len(batchCalls), *batches)
+	}
+	if len(*calls) != len(batchCalls) || len(results) != len(batchCalls) {
+		t.Fatalf("expected %d calls and results, got %d and %d", len(batchCalls), len(*calls), len(results))
+	}
+
+	for i, result := range results[:60] {
+		if result.err != nil {
+			t.Errorf("unexpected error for call %d: %s", i, result.err)
+		}
+	}
+	if last := results[60]; last.statusCode != http.StatusNotFound || last.err == nil {
+		t.Errorf("expected a 404 error for the last call, got %d (%v)", last.statusCode, last.err)
+	}
+}
+
+func TestBatchMembers_perMemberFailures(t *testing.T) {
+	server, calls, _ := testBatchServer(func(method, path string) int {
+		if method == http.MethodDelete && strings.HasSuffix(path, "/denied@domain.ext") {
+			return http.StatusForbidden
+		}
+		if method == http.MethodDelete && strings.HasSuffix(path, "/gone@domain.ext") {
+			return http.StatusNotFound
+		}
+		return http.StatusOK
+	})
+	defer server.Close()
+
+	config := &Config{client: server.Client(), batchURL: server.URL, TimeoutMinutes: 1}
+
+	failed := batchDeleteMembers("g@domain.ext", []string{"a@domain.ext", "denied@domain.ext", "gone@domain.ext"}, config)
+	if len(failed) != 1 || failed[0] != "denied@domain.ext" {
+		t.Errorf("expected only denied@domain.ext to fail, got %v", failed)
+	}
+
+	cfgMap := map[string]map[string]interface{}{
+		"a@domain.ext":      {"role": "member", "delivery_settings": "ALL_MAIL"},
+		"b@domain.ext":      {"role": "OWNER", "delivery_settings": ""},
+		"digest@domain.ext": {"role": "MEMBER", "delivery_settings": "DIGEST"},
+	}
+	*calls = (*calls)[:0]
+	failed = batchInsertMembers("g@domain.ext", []string{"a@domain.ext", "b@domain.ext", "digest@domain.ext"}, cfgMap, config)
+	if len(failed) != 1 || failed[0] != "digest@domain.ext" {
+		t.Errorf("expected members with delivery settings to be left to upsertMember, got %v", failed)
+	}
+	if len(*calls) != 2 {
+		t.Errorf("expected 2 batched inserts, got %v", *calls)
+	}
+}
+
+func TestExecuteBatch_batchFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		http.Error(w, `{"error":{"code":403,"message":"denied"}}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	config := &Config{client: server.Client(), batchURL: server.URL, TimeoutMinutes: 1}
+
+	emails := []string{"a@domain.ext", "b@domain.ext"}
+	if failed := batchDeleteMembers("g@domain.ext", emails, config); len(failed) != len(emails) {
+		t.Errorf("expected all members to fall back when the batch fails, got %v", failed)
+	}
+}
@@ -0,0 +1,56 @@
+package gsuite
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// concurrencyTransport limits the number of requests in flight, the slots are
+// shared by all clients of a provider so bursts of parallel resources are
+// smoothed out before they hit the API quota.
+type concurrencyTransport struct {
+	slots chan struct{}
+	next  http.RoundTripper
+}
+
+func newConcurrencyTransport(slots chan struct{}, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &concurrencyTransport{
+		slots: slots,
+		next:  next,
+	}
+}
+
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := func() { <-t.slots }
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// The request is in flight until its body has been read
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
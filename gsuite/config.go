@@ -2,33 +2,62 @@ package gsuite
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/pathorcontents"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"golang.org/x/oauth2/jwt"
 	directory "google.golang.org/api/admin/directory/v1"
+	reports "google.golang.org/api/admin/reports/v1"
+	gmail "google.golang.org/api/gmail/v1"
 	groupSettings "google.golang.org/api/groupssettings/v1"
 	"google.golang.org/api/impersonate"
 	"google.golang.org/api/option"
 )
 
+// providerVersion is the version of the provider, it is set at build time with
+// -ldflags "-X github.com/DeviaVir/terraform-provider-gsuite/gsuite.providerVersion=<version>".
+var providerVersion = "dev"
+
 var defaultOauthScopes = []string{
 	directory.AdminDirectoryGroupScope,
 	directory.AdminDirectoryUserScope,
 	directory.AdminDirectoryUserschemaScope,
+	groupSettings.AppsGroupsSettingsScope,
 }
 
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+const defaultTokenURL = "https://oauth2.googleapis.com/token"
+
+const gmailScopePrefix = "https://www.googleapis.com/auth/gmail."
+
 // Config is the structure used to instantiate the GSuite provider.
 type Config struct {
 	Credentials string
+
+	// ServiceAccountKey is the service account key configured as a block,
+	// used instead of Credentials when set.
+	ServiceAccountKey *accountFile
+
 	// Only users with access to the Admin APIs can access the Admin SDK Directory API,
 	// therefore the service account needs to impersonate one of those users to access the Admin SDK Directory API.
 	// See https://developers.google.com/admin-sdk/directory/v1/guides/delegation
@@ -42,35 +71,292 @@ type Config struct {
 
 	UpdateExisting bool
 
+	// SkipDomainCheck disables the plan time check of user and group emails
+	// against the domains of the customer.
+	SkipDomainCheck bool
+
+	// ReadProjection and ReadViewType are the projection and view type users
+	// are read with, unless a resource overrides them.
+	ReadProjection string
+	ReadViewType   string
+
+	// TokenURL is the OAuth 2.0 token endpoint used to exchange the service
+	// account JWT, for environments that proxy Google's endpoint.
+	TokenURL string
+
+	// RetryConfig enables retries of rate limited and failed requests at the
+	// transport level when set.
+	RetryConfig *RetryConfig
+
+	// ProxyURL is the proxy all requests are sent through, including the
+	// token requests.
+	ProxyURL string
+
+	// DebugAPICalls logs a line for every API call, without its bodies.
+	DebugAPICalls bool
+
+	// MaxConcurrentRequests limits the number of API calls in flight, zero
+	// means no limit.
+	MaxConcurrentRequests int
+
+	// CACertificate is the path to or the contents of PEM encoded CA
+	// certificates trusted in addition to the system certificates.
+	CACertificate string
+
+	// ServiceAccount is the service account impersonated through the IAM
+	// Credentials API when authenticating with Application Default Credentials.
+	// Defaults to ImpersonatedUserEmail to stay compatible with older setups.
+	ServiceAccount string
+
+	// tokenSource replaces the Application Default Credentials lookup, this
+	// allows tests to supply fake credentials.
+	tokenSource oauth2.TokenSource
+
+	// iamTransport replaces the transport of the IAM Credentials API and
+	// token exchange calls made when impersonating, this allows tests to fake
+	// them.
+	iamTransport http.RoundTripper
+
 	directory *directory.Service
 
 	groupSettings *groupSettings.Service
+
+	gmail *gmail.Service
+
+	reports *reports.Service
+
+	// client is the authenticated client shared by the services
+	client *http.Client
+
+	// batchURL overrides the endpoint of directory batch requests
+	batchURL string
+
+	// subjectClient creates a client acting on behalf of another user of the
+	// domain, which the Gmail API requires to manage the settings of a user.
+	subjectClient func(subject string, scopes []string) (*http.Client, error)
+
+	userAgent string
+
+	// customerIDCache holds the customer ID resolved from the impersonated
+	// user, it is shared by the copies of the config.
+	customerIDCache *customerIDCache
+
+	// customerDomainsCache holds the domains and domain aliases of the
+	// customer, it is shared by the copies of the config.
+	customerDomainsCache *customerDomainsCache
+
+	// subjectConfigs holds the configs acting on behalf of the users that
+	// resources impersonate instead of the impersonated user. The configs
+	// carry the settings of the provider, so each provider has its own.
+	subjectConfigs *subjectConfigCache
+
+	// subjectClients holds the clients of those users, they are shared by
+	// identically configured providers so that they also share their tokens.
+	subjectClients *subjectClientCache
+
+	// gmailServices holds the Gmail services acting on behalf of the users
+	// whose settings are managed, per user and scopes. They are shared like
+	// the subjectClients.
+	gmailServices *gmailServiceCache
+
+	// delegation checks that the domain-wide delegation of the service account
+	// grants the oauth scopes, it is shared by the copies of the config.
+	delegation *delegationCheck
+
+	// requestSlots holds a slot for every API call in flight when
+	// MaxConcurrentRequests is set, it is shared by the copies of the config.
+	requestSlots chan struct{}
+}
+
+type subjectConfigCache struct {
+	sync.Mutex
+	configs map[string]*Config
+}
+
+type subjectClientCache struct {
+	sync.Mutex
+	clients map[string]*http.Client
+}
+
+type gmailServiceCache struct {
+	sync.Mutex
+	services map[string]*gmail.Service
+}
+
+type customerIDCache struct {
+	sync.Mutex
+	id string
+}
+
+type customerDomainsCache struct {
+	sync.Mutex
+	domains  map[string]bool
+	verified map[string]bool
+	err      error
+}
+
+// serviceCache shares the clients and services of identically configured
+// providers, e.g. many aliases, so that they also share their tokens. Only
+// what is derived from the serviceCacheKey is shared, the other settings of a
+// provider, like its customer_id, stay its own.
+var serviceCache = struct {
+	sync.Mutex
+	entries map[string]*serviceCacheEntry
+}{entries: map[string]*serviceCacheEntry{}}
+
+type serviceCacheEntry struct {
+	once   sync.Once
+	config *Config
+	err    error
+}
+
+// serviceCacheKey identifies everything the clients and services are built
+// from.
+func (c *Config) serviceCacheKey(terraformVersion string) string {
+	scopes := append([]string{}, c.OauthScopes...)
+	sort.Strings(scopes)
+
+	retryConfig := ""
+	if c.RetryConfig != nil {
+		retryConfig = fmt.Sprintf("%+v", *c.RetryConfig)
+	}
+
+	serviceAccountKey := ""
+	if c.ServiceAccountKey != nil {
+		serviceAccountKey = fmt.Sprintf("%+v", *c.ServiceAccountKey)
+	}
+
+	hash := sha256.New()
+	for _, part := range []string{
+		c.Credentials,
+		serviceAccountKey,
+		c.ImpersonatedUserEmail,
+		c.ServiceAccount,
+		strings.Join(scopes, ","),
+		c.TokenURL,
+		c.ProxyURL,
+		c.CACertificate,
+		strconv.FormatBool(c.DebugAPICalls),
+		strconv.Itoa(c.TimeoutMinutes),
+		strconv.Itoa(c.MaxConcurrentRequests),
+		retryConfig,
+		terraformVersion,
+	} {
+		// Separate the parts so that they can't run into each other
+		fmt.Fprintf(hash, "%d:%s;", len(part), part)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
 // loadAndValidate loads the application default credentials from the
-// environment and creates a client for communicating with Google APIs.
+// environment and creates a client for communicating with Google APIs. The
+// clients and services are reused when an identical config was loaded before.
 func (c *Config) loadAndValidate(terraformVersion string) error {
+	// Fake credentials of tests are never shared
+	if c.tokenSource != nil {
+		return c.buildServices(terraformVersion)
+	}
+
+	key := c.serviceCacheKey(terraformVersion)
+
+	serviceCache.Lock()
+	entry, ok := serviceCache.entries[key]
+	if !ok {
+		entry = &serviceCacheEntry{}
+		serviceCache.entries[key] = entry
+	}
+	serviceCache.Unlock()
+
+	entry.once.Do(func() {
+		config := *c
+		// Don't let later changes to the scopes of c alter the cached services
+		config.OauthScopes = append([]string{}, c.OauthScopes...)
+		entry.err = config.buildServices(terraformVersion)
+		entry.config = &config
+	})
+
+	if entry.err != nil {
+		// Don't keep failures around, the next configure tries again
+		serviceCache.Lock()
+		if serviceCache.entries[key] == entry {
+			delete(serviceCache.entries, key)
+		}
+		serviceCache.Unlock()
+		return entry.err
+	}
+
+	if ok {
+		log.Printf("[INFO] Reusing the gsuite clients of an identical provider config")
+	}
+	c.client = entry.config.client
+	c.subjectClient = entry.config.subjectClient
+	c.userAgent = entry.config.userAgent
+	c.directory = entry.config.directory
+	c.groupSettings = entry.config.groupSettings
+	c.gmail = entry.config.gmail
+	c.reports = entry.config.reports
+	c.customerIDCache = entry.config.customerIDCache
+	c.subjectClients = entry.config.subjectClients
+	c.gmailServices = entry.config.gmailServices
+	// The domains are those of the customer_id, which isn't part of the key,
+	// and the configs of other users copy the settings of this provider
+	c.customerDomainsCache = &customerDomainsCache{}
+	c.subjectConfigs = &subjectConfigCache{configs: map[string]*Config{}}
+	c.delegation = entry.config.delegation
+	c.requestSlots = entry.config.requestSlots
+	return nil
+}
+
+// buildServices creates the clients and services of the config.
+func (c *Config) buildServices(terraformVersion string) error {
 	log.Println("[INFO] Building gsuite client config structure")
 	var account accountFile
 
 	oauthScopes := c.OauthScopes
 
+	if c.MaxConcurrentRequests > 0 {
+		c.requestSlots = make(chan struct{}, c.MaxConcurrentRequests)
+	}
+
 	var client *http.Client
 	clientOptions := []option.ClientOption{}
 
-	if c.Credentials != "" {
+	// The token requests use the client of the context, as do the clients
+	// built from the token sources.
+	ctx := context.Background()
+	transport, err := c.baseTransport()
+	if err != nil {
+		return err
+	}
+	if transport != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
+	}
+
+	if c.Credentials != "" || c.ServiceAccountKey != nil {
 		if c.ImpersonatedUserEmail == "" {
 			return fmt.Errorf("required field missing: impersonated_user_email")
 		}
 
-		contents, _, err := pathorcontents.Read(c.Credentials)
-		if err != nil {
-			return fmt.Errorf("Error loading credentials: %s", err)
-		}
+		if c.ServiceAccountKey != nil {
+			if err := validateServiceAccountKey(c.ServiceAccountKey); err != nil {
+				return err
+			}
+			account = *c.ServiceAccountKey
+		} else {
+			contents, _, err := pathorcontents.Read(c.Credentials)
+			if err != nil {
+				return fmt.Errorf("Error loading credentials: %s", err)
+			}
+
+			contents, err = credentialsJSON(contents)
+			if err != nil {
+				return err
+			}
 
-		// Assume account_file is a JSON string
-		if err := parseJSON(&account, contents); err != nil {
-			return fmt.Errorf("Error parsing credentials '%s': %s", contents, err)
+			// Assume account_file is a JSON string
+			if err := parseJSON(&account, contents); err != nil {
+				return fmt.Errorf("Error parsing credentials '%s': %s", contents, err)
+			}
 		}
 
 		// Get the token for use in our requests
@@ -78,50 +364,115 @@ func (c *Config) loadAndValidate(terraformVersion string) error {
 		log.Printf("[INFO]   -- Email: %s", account.ClientEmail)
 		log.Printf("[INFO]   -- Scopes: %s", oauthScopes)
 		log.Printf("[INFO]   -- Private Key Length: %d", len(account.PrivateKey))
+		log.Printf("[INFO]   -- Token URL: %s", c.tokenURL())
 
-		conf := jwt.Config{
-			Email:      account.ClientEmail,
-			PrivateKey: []byte(account.PrivateKey),
-			Scopes:     oauthScopes,
-			TokenURL:   "https://oauth2.googleapis.com/token",
-		}
-
-		conf.Subject = c.ImpersonatedUserEmail
+		conf := c.jwtConfig(account)
 
 		// Initiate an http.Client. The following GET request will be
 		// authorized and authenticated on the behalf of
-		// your service account.
-		client = conf.Client(context.Background())
-	} else if c.ImpersonatedUserEmail != "" {
-		tokenSource, err := impersonate.CredentialsTokenSource(context.Background(), impersonate.CredentialsConfig{
-			TargetPrincipal: c.ImpersonatedUserEmail,
-			Scopes:          oauthScopes,
-			Subject:         c.ImpersonatedUserEmail,
-		})
-		if err != nil {
-			return errors.Wrap(err, "failed to create impersonated token source")
+		// your service account. The delegation check shares the token source,
+		// so its token is reused by the requests.
+		tokenSource := conf.TokenSource(ctx)
+		client = oauth2.NewClient(ctx, tokenSource)
+
+		c.delegation = &delegationCheck{
+			tokenSource: tokenSource,
+			clientID:    account.ClientId,
+			clientEmail: account.ClientEmail,
+			subject:     c.ImpersonatedUserEmail,
+			scopes:      oauthScopes,
+			probe: func(scopes []string) error {
+				probeConf := c.jwtConfig(account)
+				probeConf.Scopes = scopes
+				_, err := probeConf.TokenSource(ctx).Token()
+				return err
+			},
 		}
-		clientOptions = append(clientOptions, option.WithTokenSource(tokenSource))
 
+		c.subjectClient = func(subject string, scopes []string) (*http.Client, error) {
+			subjectConf := c.jwtConfig(account)
+			subjectConf.Subject = subject
+			subjectConf.Scopes = scopes
+			return c.wrapTransport(subjectConf.Client(ctx)), nil
+		}
 	} else {
-		log.Printf("[INFO] Authenticating using DefaultClient")
-		err := error(nil)
-		client, err = google.DefaultClient(context.Background(), oauthScopes...)
-		if err != nil {
-			return errors.Wrap(err, "failed to create client")
+		log.Printf("[INFO] Authenticating using Application Default Credentials")
+
+		tokenSource := c.tokenSource
+		if tokenSource == nil {
+			// When impersonating, the default credentials are only used to call
+			// the IAM Credentials API, the admin scopes are requested on the
+			// impersonated token instead.
+			scopes := oauthScopes
+			if c.ImpersonatedUserEmail != "" {
+				scopes = []string{cloudPlatformScope}
+			}
+
+			creds, err := google.FindDefaultCredentials(ctx, scopes...)
+			if err != nil {
+				return errors.Wrap(err, "failed to find default credentials")
+			}
+			tokenSource = creds.TokenSource
 		}
+
+		// Federated and other default credentials cannot sign a JWT for
+		// domain-wide delegation themselves, so we ask the IAM Credentials API
+		// to do so on behalf of the service account.
+		if c.ImpersonatedUserEmail != "" {
+			targetPrincipal := c.ServiceAccount
+			if targetPrincipal == "" {
+				targetPrincipal = c.ImpersonatedUserEmail
+			}
+
+			log.Printf("[INFO]   -- Service Account: %s", targetPrincipal)
+			log.Printf("[INFO]   -- Subject: %s", c.ImpersonatedUserEmail)
+
+			baseTokenSource := tokenSource
+			iamOption := option.WithTokenSource(baseTokenSource)
+			if transport != nil {
+				// The IAM Credentials API client would not use the transport otherwise
+				iamOption = option.WithHTTPClient(oauth2.NewClient(ctx, baseTokenSource))
+			}
+			if c.iamTransport != nil {
+				iamOption = option.WithHTTPClient(&http.Client{Transport: &oauth2.Transport{Source: baseTokenSource, Base: c.iamTransport}})
+			}
+
+			var err error
+			tokenSource, err = impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+				TargetPrincipal: targetPrincipal,
+				Scopes:          oauthScopes,
+				Subject:         c.ImpersonatedUserEmail,
+			}, iamOption)
+			if err != nil {
+				return errors.Wrap(err, "failed to create impersonated token source")
+			}
+
+			c.subjectClient = func(subject string, scopes []string) (*http.Client, error) {
+				subjectTokenSource, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+					TargetPrincipal: targetPrincipal,
+					Scopes:          scopes,
+					Subject:         subject,
+				}, iamOption)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to create impersonated token source")
+				}
+				return c.wrapTransport(oauth2.NewClient(ctx, subjectTokenSource)), nil
+			}
+		}
+
+		client = oauth2.NewClient(ctx, tokenSource)
 	}
 
 	// Use a custom user-agent string. This helps google with analytics and it's
 	// just a nice thing to do.
 	if client != nil {
-		client.Transport = logging.NewTransport("Google", client.Transport)
-		clientOptions = append(clientOptions, option.WithHTTPClient(client))
+		c.client = c.wrapTransport(client)
+		clientOptions = append(clientOptions, option.WithHTTPClient(c.client))
 
 	}
 
-	userAgent := fmt.Sprintf("(%s %s) Terraform/%s",
-		runtime.GOOS, runtime.GOARCH, terraformVersion)
+	userAgent := providerUserAgent(terraformVersion)
+	c.userAgent = userAgent
 	context := context.Background()
 
 	// Create the directory service.
@@ -140,9 +491,563 @@ func (c *Config) loadAndValidate(terraformVersion string) error {
 	groupSettingsSvc.UserAgent = userAgent
 	c.groupSettings = groupSettingsSvc
 
+	// Create the gmail service.
+	gmailSvc, err := gmail.NewService(context, clientOptions...)
+	if err != nil {
+		return err
+	}
+	gmailSvc.UserAgent = userAgent
+	c.gmail = gmailSvc
+
+	// Create the reports service.
+	reportsSvc, err := reports.NewService(context, clientOptions...)
+	if err != nil {
+		return err
+	}
+	reportsSvc.UserAgent = userAgent
+	c.reports = reportsSvc
+
+	c.customerIDCache = &customerIDCache{}
+	c.customerDomainsCache = &customerDomainsCache{}
+	c.subjectConfigs = &subjectConfigCache{configs: map[string]*Config{}}
+	c.subjectClients = &subjectClientCache{clients: map[string]*http.Client{}}
+	c.gmailServices = &gmailServiceCache{services: map[string]*gmail.Service{}}
+
+	return nil
+}
+
+// providerUserAgent returns the user-agent sent with the API calls, it names
+// the provider version so the calls of a release can be told apart.
+func providerUserAgent(terraformVersion string) string {
+	return fmt.Sprintf("(%s %s) Terraform/%s terraform-provider-gsuite/%s",
+		runtime.GOOS, runtime.GOARCH, terraformVersion, providerVersion)
+}
+
+// resolvedCustomerID returns the configured customer ID, or resolves the ID
+// of the customer of the impersonated user when none is configured. Some
+// calls don't accept the my_customer alias.
+func (c *Config) resolvedCustomerID() (string, error) {
+	if c.CustomerId != "" && c.CustomerId != "my_customer" {
+		return c.CustomerId, nil
+	}
+
+	if c.customerIDCache != nil {
+		c.customerIDCache.Lock()
+		defer c.customerIDCache.Unlock()
+		if c.customerIDCache.id != "" {
+			return c.customerIDCache.id, nil
+		}
+	}
+
+	if c.ImpersonatedUserEmail == "" {
+		return "", fmt.Errorf("[ERROR] Unable to resolve the customer ID without an impersonated user, set customer_id in the provider")
+	}
+
+	var user *directory.User
+	var err error
+	err = retry(func() error {
+		user, err = c.directory.Users.Get(c.ImpersonatedUserEmail).Fields("customerId").Do()
+		return err
+	}, c.TimeoutMinutes)
+
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error resolving the customer ID of %s: %s", c.ImpersonatedUserEmail, err)
+	}
+	if user.CustomerId == "" {
+		return "", fmt.Errorf("[ERROR] No customer ID returned for %s, set customer_id in the provider", c.ImpersonatedUserEmail)
+	}
+
+	log.Printf("[INFO] Resolved customer ID %s from %s", user.CustomerId, c.ImpersonatedUserEmail)
+	if c.customerIDCache != nil {
+		c.customerIDCache.id = user.CustomerId
+	}
+	return user.CustomerId, nil
+}
+
+// customerDomains returns the lowercased domains and domain aliases of the
+// customer, listing them once.
+func (c *Config) customerDomains() (map[string]bool, error) {
+	domains, _, err := c.listCustomerDomains()
+	return domains, err
+}
+
+// customerVerifiedDomains returns the lowercased domains and domain aliases of
+// the customer which are verified, only those can hold users and groups.
+func (c *Config) customerVerifiedDomains() (map[string]bool, error) {
+	_, verified, err := c.listCustomerDomains()
+	return verified, err
+}
+
+// listCustomerDomains returns all and only the verified domains and domain
+// aliases of the customer, listing them once. A failure is kept as well, e.g.
+// without a domain scope every call would fail alike.
+func (c *Config) listCustomerDomains() (map[string]bool, map[string]bool, error) {
+	if c.customerDomainsCache != nil {
+		c.customerDomainsCache.Lock()
+		defer c.customerDomainsCache.Unlock()
+		if c.customerDomainsCache.domains != nil || c.customerDomainsCache.err != nil {
+			return c.customerDomainsCache.domains, c.customerDomainsCache.verified, c.customerDomainsCache.err
+		}
+	}
+
+	var response *directory.Domains2
+	var err error
+	err = retry(func() error {
+		response, err = c.directory.Domains.List(c.CustomerId).Do()
+		return err
+	}, c.TimeoutMinutes)
+
+	if err != nil {
+		err = fmt.Errorf("[ERROR] Error listing the domains of customer %s: %s", c.CustomerId, err)
+		if c.customerDomainsCache != nil {
+			c.customerDomainsCache.err = err
+		}
+		return nil, nil, err
+	}
+
+	domains := map[string]bool{}
+	verified := map[string]bool{}
+	for _, domain := range response.Domains {
+		domains[strings.ToLower(domain.DomainName)] = true
+		if domain.Verified {
+			verified[strings.ToLower(domain.DomainName)] = true
+		}
+		for _, alias := range domain.DomainAliases {
+			domains[strings.ToLower(alias.DomainAliasName)] = true
+			if alias.Verified {
+				verified[strings.ToLower(alias.DomainAliasName)] = true
+			}
+		}
+	}
+
+	if c.customerDomainsCache != nil {
+		c.customerDomainsCache.domains = domains
+		c.customerDomainsCache.verified = verified
+	}
+	return domains, verified, nil
+}
+
+// wrapTransport adds request logging and, when configured, a concurrency
+// limit and retries to the transport of the client.
+func (c *Config) wrapTransport(client *http.Client) *http.Client {
+	client.Transport = logging.NewTransport("Google", client.Transport)
+	if c.DebugAPICalls {
+		client.Transport = newAPICallLogTransport(client.Transport)
+	}
+	if c.TimeoutMinutes > 0 {
+		client.Transport = newTimeoutTransport(time.Duration(c.TimeoutMinutes)*time.Minute, client.Transport)
+	}
+	// Waiting for a slot doesn't count towards the timeout of a request, and
+	// no slot is held during the backoff of a retry
+	if c.requestSlots != nil {
+		client.Transport = newConcurrencyTransport(c.requestSlots, client.Transport)
+	}
+	if c.RetryConfig != nil {
+		client.Transport = newRetryTransport(*c.RetryConfig, client.Transport)
+	}
+	return client
+}
+
+// withTimeout returns a copy of the config whose services fail all requests
+// once the timeout of the operation has passed, e.g. from a timeouts block.
+func (c *Config) withTimeout(operation string, timeout time.Duration) (*Config, error) {
+	if c.client == nil || timeout <= 0 {
+		return c, nil
+	}
+
+	client := &http.Client{
+		Transport: newDeadlineTransport(operation, time.Now().Add(timeout), c.client.Transport),
+	}
+
+	return c.withClient(client)
+}
+
+// withClient returns a copy of the config whose directory and group settings
+// services use the given client.
+func (c *Config) withClient(client *http.Client) (*Config, error) {
+	clientOptions := []option.ClientOption{option.WithHTTPClient(client)}
+
+	config := *c
+	config.client = client
+	directorySvc, err := directory.NewService(context.Background(), clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	directorySvc.UserAgent = c.userAgent
+	config.directory = directorySvc
+
+	groupSettingsSvc, err := groupSettings.NewService(context.Background(), clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	groupSettingsSvc.UserAgent = c.userAgent
+	config.groupSettings = groupSettingsSvc
+
+	return &config, nil
+}
+
+// impersonating returns a copy of the config acting on behalf of another user
+// of the domain, with the same oauth scopes. The copies are cached per user.
+func (c *Config) impersonating(subject string) (*Config, error) {
+	if subject == "" || strings.EqualFold(subject, c.ImpersonatedUserEmail) {
+		return c, nil
+	}
+	if c.subjectClient == nil {
+		return nil, fmt.Errorf("[ERROR] Impersonating %s requires service account credentials or an impersonated service_account in the provider", subject)
+	}
+
+	key := strings.ToLower(subject)
+	if c.subjectConfigs != nil {
+		c.subjectConfigs.Lock()
+		defer c.subjectConfigs.Unlock()
+		if config, ok := c.subjectConfigs.configs[key]; ok {
+			return config, nil
+		}
+	}
+
+	client, err := c.subjectHTTPClient(key)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := c.withClient(client)
+	if err != nil {
+		return nil, err
+	}
+	config.ImpersonatedUserEmail = subject
+
+	gmailSvc, err := gmail.NewService(context.Background(), option.WithHTTPClient(client))
+	if err != nil {
+		return nil, err
+	}
+	gmailSvc.UserAgent = c.userAgent
+	config.gmail = gmailSvc
+
+	if c.subjectConfigs != nil {
+		c.subjectConfigs.configs[key] = config
+	}
+	return config, nil
+}
+
+// subjectHTTPClient returns the client acting on behalf of another user, with
+// the oauth scopes of the provider.
+func (c *Config) subjectHTTPClient(subject string) (*http.Client, error) {
+	if c.subjectClients != nil {
+		c.subjectClients.Lock()
+		defer c.subjectClients.Unlock()
+		if client, ok := c.subjectClients.clients[subject]; ok {
+			return client, nil
+		}
+	}
+
+	log.Printf("[INFO] Creating a client impersonating %s", subject)
+	client, err := c.subjectClient(subject, c.OauthScopes)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error creating a client for %s: %s", subject, err)
+	}
+
+	if c.subjectClients != nil {
+		c.subjectClients.clients[subject] = client
+	}
+	return client, nil
+}
+
+// gmailService returns a Gmail service acting on behalf of the given user. The
+// Gmail API only allows users to manage their own settings, so a delegated
+// token is requested for every other user, using the configured gmail scopes.
+// The services are cached per user and scopes.
+func (c *Config) gmailService(userEmail string) (*gmail.Service, error) {
+	if strings.EqualFold(userEmail, c.ImpersonatedUserEmail) {
+		return c.gmail, nil
+	}
+	if c.subjectClient == nil {
+		return nil, fmt.Errorf("[ERROR] Managing the mailbox of %s requires service account credentials or an impersonated service_account in the provider, other credentials only act on the mailbox of the impersonated_user_email", userEmail)
+	}
+
+	var scopes []string
+	for _, scope := range c.OauthScopes {
+		if strings.HasPrefix(scope, gmailScopePrefix) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	key := strings.ToLower(userEmail) + " " + strings.Join(scopes, " ")
+	if c.gmailServices != nil {
+		c.gmailServices.Lock()
+		defer c.gmailServices.Unlock()
+		if svc, ok := c.gmailServices.services[key]; ok {
+			return svc, nil
+		}
+	}
+
+	client, err := c.subjectClient(userEmail, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error creating a client for %s: %s", userEmail, err)
+	}
+
+	svc, err := gmail.NewService(context.Background(), option.WithHTTPClient(client))
+	if err != nil {
+		return nil, err
+	}
+	svc.UserAgent = c.userAgent
+
+	if c.gmailServices != nil {
+		c.gmailServices.services[key] = svc
+	}
+	return svc, nil
+}
+
+// baseTransport returns a copy of the default transport which sends all
+// requests through the proxy and trusts the CA certificate of the config, or
+// nil when neither is configured.
+func (c *Config) baseTransport() (*http.Transport, error) {
+	if c.ProxyURL == "" && c.CACertificate == "" {
+		return nil, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.ProxyURL != "" {
+		u, err := parseProxyURL(c.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] Sending all requests through the proxy_url")
+		transport.Proxy = http.ProxyURL(u)
+	}
+
+	if c.CACertificate != "" {
+		contents, _, err := pathorcontents.Read(c.CACertificate)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading ca_certificate: %s", err)
+		}
+		pool, err := certPool(contents)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] Trusting the ca_certificate in addition to the system certificates")
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return transport, nil
+}
+
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy_url %q: %s", proxyURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy_url %q: expected an http, https or socks5 URL", proxyURL)
+	}
+	return u, nil
+}
+
+// certPool returns the system certificates together with the PEM encoded
+// certificates, e.g. of a corporate CA inspecting TLS.
+func certPool(contents string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(contents)) {
+		return nil, fmt.Errorf("Error parsing ca_certificate: no PEM encoded certificate found")
+	}
+	return pool, nil
+}
+
+func (c *Config) tokenURL() string {
+	if c.TokenURL != "" {
+		return c.TokenURL
+	}
+	return defaultTokenURL
+}
+
+// requireScope returns an error naming the missing scope when none of the
+// given scopes is configured.
+func (c *Config) requireScope(name string, scopes ...string) error {
+	for _, s := range c.OauthScopes {
+		for _, scope := range scopes {
+			if s == scope {
+				return nil
+			}
+		}
+	}
+	if len(scopes) == 1 {
+		return fmt.Errorf("[ERROR] %s requires the %q oauth scope, add it to the oauth_scopes of the provider", name, scopes[0])
+	}
+	return fmt.Errorf("[ERROR] %s requires one of the %q oauth scopes, add one of them to the oauth_scopes of the provider", name, scopes)
+}
+
+// delegationCheck requests the token of the service account once, to tell a
+// domain-wide delegation lacking some of the oauth scopes apart from other
+// errors.
+type delegationCheck struct {
+	sync.Mutex
+	tokenSource oauth2.TokenSource
+	clientID    string
+	clientEmail string
+	subject     string
+	scopes      []string
+	// probe requests a token for only the given scopes
+	probe func(scopes []string) error
+
+	done    bool
+	missing []string
+	// refused is the classified error of a token Google refused for another
+	// reason than the scopes
+	refused error
+}
+
+// isUnauthorizedClientError reports whether Google refused a token because the
+// delegation does not grant all of the requested scopes.
+func isUnauthorizedClientError(err error) bool {
+	if rerr, ok := err.(*oauth2.RetrieveError); ok {
+		return strings.Contains(string(rerr.Body), "unauthorized_client")
+	}
+	return err != nil && strings.Contains(err.Error(), "unauthorized_client")
+}
+
+// tokenErrorResponse is the error returned by the OAuth 2.0 token endpoint.
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// tokenErrorCodes are the token errors with a known remedy.
+var tokenErrorCodes = []string{"access_denied", "invalid_grant", "unauthorized_client"}
+
+// parseTokenError returns the error code and description of a token request
+// Google refused. The code is empty for other errors, e.g. network errors.
+func parseTokenError(err error) (string, string) {
+	if err == nil {
+		return "", ""
+	}
+
+	var rerr *oauth2.RetrieveError
+	if errors.As(err, &rerr) {
+		var response tokenErrorResponse
+		if json.Unmarshal(rerr.Body, &response) == nil && response.Error != "" {
+			return response.Error, response.ErrorDescription
+		}
+	}
+
+	// The error may have lost its type on the way, e.g. when wrapped as string
+	for _, code := range tokenErrorCodes {
+		if strings.Contains(err.Error(), code) {
+			return code, ""
+		}
+	}
+	return "", ""
+}
+
+// tokenError explains why Google refused a token of the service account and
+// how to fix it. It returns nil for errors without a known remedy.
+func tokenError(err error, clientID, clientEmail, subject string, scopes []string) error {
+	code, description := parseTokenError(err)
+	if description != "" {
+		description = ": " + description
+	}
+
+	client := "client ID " + clientID
+	if clientID == "" {
+		client = "the client ID of " + clientEmail
+	}
+
+	switch code {
+	case "invalid_grant":
+		return fmt.Errorf("[ERROR] Google refused the credentials of %s (invalid_grant%s): the service account key may be deleted or disabled, or the clock of this machine is off, which invalidates the signed JWT. Check that the key of %s is still listed for the service account and that the clock is synchronized", clientEmail, description, client)
+	case "unauthorized_client":
+		return fmt.Errorf("[ERROR] The domain-wide delegation of %s is not configured for the oauth scopes %q (unauthorized_client%s): authorize %s for them in the Google Admin console under Security > API controls > Domain-wide delegation", clientEmail, scopes, description, client)
+	case "access_denied":
+		return fmt.Errorf("[ERROR] Google denied %s acting on behalf of %s (access_denied%s): make sure the impersonated_user_email is an active admin whose privileges cover the oauth scopes %q", clientEmail, subject, description, scopes)
+	}
 	return nil
 }
 
+// unauthorizedScopes returns the configured scopes that the domain-wide
+// delegation of the service account does not grant, and the classified error
+// of credentials Google refused otherwise. The token request is only made
+// once, errors without a known remedy are left to the requests and not
+// checked again.
+func (d *delegationCheck) unauthorizedScopes() ([]string, error) {
+	d.Lock()
+	defer d.Unlock()
+	if d.done {
+		return d.missing, d.refused
+	}
+	d.done = true
+
+	_, err := d.tokenSource.Token()
+	if err != nil && !isUnauthorizedClientError(err) {
+		if refused := tokenError(err, d.clientID, d.clientEmail, d.subject, d.scopes); refused != nil {
+			d.refused = refused
+			return nil, refused
+		}
+		log.Printf("[WARN] Unable to check the domain-wide delegation of %s: %s", d.clientEmail, err)
+		return nil, nil
+	}
+
+	if err != nil {
+		// Google refuses the token as a whole, so find the culprits one by one
+		for _, scope := range d.scopes {
+			if err := d.probe([]string{scope}); isUnauthorizedClientError(err) {
+				d.missing = append(d.missing, scope)
+			}
+		}
+		if len(d.missing) == 0 {
+			d.missing = d.scopes
+		}
+	}
+	return d.missing, nil
+}
+
+// requireDelegation returns an error naming the client ID to authorize when
+// the domain-wide delegation of the service account does not grant all the
+// configured scopes, or explaining why Google refused the credentials, either
+// fails every request of the resource.
+func (c *Config) requireDelegation(name string, scopes ...string) error {
+	if c.delegation == nil {
+		return nil
+	}
+	missing, err := c.delegation.unauthorizedScopes()
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return delegationError(name, scopes, missing, c.delegation.clientID, c.delegation.clientEmail)
+}
+
+func delegationError(name string, scopes, missing []string, clientID, clientEmail string) error {
+	client := "client ID " + clientID
+	if clientID == "" {
+		client = "the client ID of " + clientEmail
+	}
+
+	for _, scope := range missing {
+		for _, s := range scopes {
+			if s == scope {
+				return fmt.Errorf("[ERROR] %s requires the %q oauth scope, which the domain-wide delegation of %s does not grant: authorize %s for the oauth scopes %q in the Google Admin console under Security > API controls > Domain-wide delegation", name, scope, clientEmail, client, missing)
+			}
+		}
+	}
+	return fmt.Errorf("[ERROR] %s cannot authenticate, the domain-wide delegation of %s does not grant the oauth scopes %q: authorize %s for them in the Google Admin console under Security > API controls > Domain-wide delegation, or remove them from the oauth_scopes of the provider", name, clientEmail, missing, client)
+}
+
+// jwtConfig builds the domain-wide delegation JWT configuration for a service
+// account key.
+func (c *Config) jwtConfig(account accountFile) *jwt.Config {
+	return &jwt.Config{
+		Email:      account.ClientEmail,
+		PrivateKey: []byte(account.PrivateKey),
+		Scopes:     c.OauthScopes,
+		TokenURL:   c.tokenURL(),
+		Subject:    c.ImpersonatedUserEmail,
+	}
+}
+
 // accountFile represents the structure of the account file JSON file.
 type accountFile struct {
 	PrivateKeyId string `json:"private_key_id"`
@@ -151,6 +1056,44 @@ type accountFile struct {
 	ClientId     string `json:"client_id"`
 }
 
+// validateServiceAccountKey checks that a service_account_key holds the fields
+// needed to sign the JWT of the service account.
+func validateServiceAccountKey(account *accountFile) error {
+	var missing []string
+	if strings.TrimSpace(account.ClientEmail) == "" {
+		missing = append(missing, "client_email")
+	}
+	if strings.TrimSpace(account.PrivateKey) == "" {
+		missing = append(missing, "private_key")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required field missing in service_account_key: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// credentialsJSON returns the JSON of credentials, which secret stores may
+// hand out base64 encoded. Contents that are not base64 are returned as is.
+func credentialsJSON(contents string) (string, error) {
+	trimmed := strings.TrimSpace(contents)
+	if strings.HasPrefix(trimmed, "{") {
+		return contents, nil
+	}
+
+	trimmed = strings.Join(strings.Fields(trimmed), "")
+	decoded, err := base64.StdEncoding.DecodeString(trimmed)
+	if err != nil {
+		if decoded, err = base64.RawStdEncoding.DecodeString(trimmed); err != nil {
+			return contents, nil
+		}
+	}
+
+	if !json.Valid(decoded) {
+		return "", fmt.Errorf("Error parsing credentials: the base64 decoded credentials are not valid JSON")
+	}
+	return string(decoded), nil
+}
+
 func parseJSON(result interface{}, contents string) error {
 	r := strings.NewReader(contents)
 	dec := json.NewDecoder(r)
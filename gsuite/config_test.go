@@ -1,12 +1,30 @@
 package gsuite
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+
+	"golang.org/x/oauth2"
+	directory "google.golang.org/api/admin/directory/v1"
+	gmail "google.golang.org/api/gmail/v1"
 )
 
 const testFakeCredentialsPath = "./test-fixtures/fake_account.json"
 
+const testFakeCredentialsBase64Path = "./test-fixtures/fake_account.json.b64"
+
 func TestConfigLoadAndValidate_accountFilePath(t *testing.T) {
 	config := Config{
 		Credentials:           testFakeCredentialsPath,
@@ -35,6 +53,36 @@ func TestConfigLoadAndValidate_accountFileJSON(t *testing.T) {
 	}
 }
 
+func TestConfigLoadAndValidate_accountFileBase64(t *testing.T) {
+	contents, err := ioutil.ReadFile(testFakeCredentialsBase64Path)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	for _, credentials := range []string{testFakeCredentialsBase64Path, string(contents)} {
+		config := Config{
+			Credentials:           credentials,
+			ImpersonatedUserEmail: "xxx@xxx.xom",
+		}
+
+		if err := config.loadAndValidate("0.12"); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+}
+
+func TestConfigLoadAndValidate_accountFileBase64Invalid(t *testing.T) {
+	config := Config{
+		Credentials:           base64.StdEncoding.EncodeToString([]byte("{this is not json}")),
+		ImpersonatedUserEmail: "xxx@xxx.xom",
+	}
+
+	err := config.loadAndValidate("0.12")
+	if err == nil || !strings.Contains(err.Error(), "base64 decoded credentials are not valid JSON") {
+		t.Fatalf("expected an error about the decoded JSON, got %v", err)
+	}
+}
+
 func TestConfigLoadAndValidate_accountFileJSONInvalid(t *testing.T) {
 	config := Config{
 		Credentials: "{this is not json}",
@@ -73,3 +121,849 @@ func TestConfigLoadAndValidate_noImpersonatedEmail(t *testing.T) {
 		t.Fatalf("error: %v", err)
 	}
 }
+
+// testRedirectTransport sends all requests to the server instead of the
+// hosts they are meant for.
+type testRedirectTransport struct {
+	server *httptest.Server
+}
+
+func (t testRedirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = "http"
+	r.URL.Host = strings.TrimPrefix(t.server.URL, "http://")
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestConfigLoadAndValidate_defaultCredentialsImpersonation(t *testing.T) {
+	var mu sync.Mutex
+	var signed map[string]interface{}
+	var signer, apiAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Host == "iamcredentials.googleapis.com":
+			if r.Header.Get("Authorization") != "Bearer fake-federated-token" {
+				t.Errorf("expected the IAM Credentials API to be called with the federated token, got %q", r.Header.Get("Authorization"))
+			}
+			signer = r.URL.Path
+
+			var request struct {
+				Payload string `json:"payload"`
+			}
+			json.NewDecoder(r.Body).Decode(&request)
+			json.Unmarshal([]byte(request.Payload), &signed)
+			fmt.Fprint(w, `{"keyId":"key","signedJwt":"signed-jwt"}`)
+		case r.Host == "oauth2.googleapis.com" && r.URL.Path == "/token":
+			r.ParseForm()
+			if r.Form.Get("assertion") != "signed-jwt" {
+				t.Errorf("expected the signed JWT to be exchanged, got %q", r.Form.Get("assertion"))
+			}
+			fmt.Fprint(w, `{"access_token":"impersonated-token","token_type":"Bearer","expires_in":3600}`)
+		default:
+			apiAuthorization = r.Header.Get("Authorization")
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	config := Config{
+		ImpersonatedUserEmail: "admin@xxx.xom",
+		ServiceAccount:        "terraform@project.iam.gserviceaccount.com",
+		OauthScopes:           defaultOauthScopes,
+		tokenSource:           oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "fake-federated-token"}),
+		iamTransport:          testRedirectTransport{server},
+	}
+
+	err := config.loadAndValidate("0.12")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if config.directory == nil || config.groupSettings == nil {
+		t.Fatalf("expected services to be configured")
+	}
+
+	resp, err := config.client.Get(server.URL + "/admin/directory/v1/users/admin@xxx.xom")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	resp.Body.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if expected := "/v1/projects/-/serviceAccounts/terraform@project.iam.gserviceaccount.com:signJwt"; signer != expected {
+		t.Errorf("expected the JWT to be signed by %s, got %q", expected, signer)
+	}
+	if signed["iss"] != config.ServiceAccount || signed["sub"] != config.ImpersonatedUserEmail {
+		t.Errorf("expected a JWT of %s delegating to %s, got %v", config.ServiceAccount, config.ImpersonatedUserEmail, signed)
+	}
+	if apiAuthorization != "Bearer impersonated-token" {
+		t.Errorf("expected the API to be called with the impersonated token, got %q", apiAuthorization)
+	}
+}
+
+func TestConfigLoadAndValidate_defaultCredentialsImpersonationNoScopes(t *testing.T) {
+	config := Config{
+		ImpersonatedUserEmail: "admin@xxx.xom",
+		tokenSource:           oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "fake-federated-token"}),
+	}
+
+	err := config.loadAndValidate("0.12")
+	if err == nil {
+		t.Fatalf("expected error, but got nil")
+	}
+}
+
+func TestConfigLoadAndValidate_userAgent(t *testing.T) {
+	defer func(version string) { providerVersion = version }(providerVersion)
+	providerVersion = "1.2.3"
+
+	config := Config{
+		Credentials:           testFakeCredentialsPath,
+		ImpersonatedUserEmail: "xxx@xxx.xom",
+	}
+
+	// A Terraform version of its own, so the services aren't shared with
+	// other tests
+	if err := config.loadAndValidate("0.12-user-agent"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	for name, userAgent := range map[string]string{
+		"directory":     config.directory.UserAgent,
+		"groupsettings": config.groupSettings.UserAgent,
+		"gmail":         config.gmail.UserAgent,
+	} {
+		if !strings.Contains(userAgent, "Terraform/0.12-user-agent") || !strings.HasSuffix(userAgent, " terraform-provider-gsuite/1.2.3") {
+			t.Errorf("expected the %s user-agent to contain the Terraform and provider versions, got %q", name, userAgent)
+		}
+	}
+}
+
+func TestConfigJWTConfig_tokenURL(t *testing.T) {
+	account := accountFile{
+		ClientEmail: "terraform@project.iam.gserviceaccount.com",
+		PrivateKey:  "fake",
+	}
+
+	config := Config{
+		ImpersonatedUserEmail: "xxx@xxx.xom",
+	}
+	if conf := config.jwtConfig(account); conf.TokenURL != defaultTokenURL {
+		t.Fatalf("expected token url %s, got %s", defaultTokenURL, conf.TokenURL)
+	}
+
+	config.TokenURL = "https://oauth2.gateway.internal/token"
+	conf := config.jwtConfig(account)
+	if conf.TokenURL != config.TokenURL {
+		t.Fatalf("expected token url %s, got %s", config.TokenURL, conf.TokenURL)
+	}
+	if conf.Subject != config.ImpersonatedUserEmail {
+		t.Fatalf("expected subject %s, got %s", config.ImpersonatedUserEmail, conf.Subject)
+	}
+}
+
+func TestConfigGmailService_subject(t *testing.T) {
+	config := Config{
+		Credentials:           testFakeCredentialsPath,
+		ImpersonatedUserEmail: "admin@domain.ext",
+		OauthScopes: []string{
+			"https://www.googleapis.com/auth/admin.directory.user",
+			"https://www.googleapis.com/auth/gmail.settings.sharing",
+		},
+	}
+
+	if err := config.loadAndValidate("0.12"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	// Don't reuse the services cached by identical configs of other runs
+	config.gmailServices = &gmailServiceCache{services: map[string]*gmail.Service{}}
+
+	svc, err := config.gmailService("Admin@domain.ext")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if svc != config.gmail {
+		t.Fatalf("expected the shared gmail service for the impersonated user")
+	}
+
+	// Other users get their own delegated client with only the gmail scopes
+	var subject string
+	var scopes []string
+	subjectClient := config.subjectClient
+	config.subjectClient = func(s string, sc []string) (*http.Client, error) {
+		subject, scopes = s, sc
+		return subjectClient(s, sc)
+	}
+
+	svc, err = config.gmailService("jane@domain.ext")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if svc == config.gmail {
+		t.Fatalf("expected a separate gmail service for another user")
+	}
+	if subject != "jane@domain.ext" {
+		t.Fatalf("expected subject jane@domain.ext, got %s", subject)
+	}
+	if len(scopes) != 1 || scopes[0] != "https://www.googleapis.com/auth/gmail.settings.sharing" {
+		t.Fatalf("expected only the gmail scopes, got %v", scopes)
+	}
+
+	// The service of the user is reused
+	subject = ""
+	cached, err := config.gmailService("Jane@domain.ext")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if cached != svc || subject != "" {
+		t.Fatalf("expected the gmail service of jane@domain.ext to be cached")
+	}
+}
+
+func TestConfigGmailService_withoutDelegation(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	config.ImpersonatedUserEmail = "admin@domain.ext"
+
+	svc, err := config.gmailService("Admin@domain.ext")
+	if err != nil || svc != config.gmail {
+		t.Fatalf("expected the shared gmail service for the impersonated user, got %v", err)
+	}
+
+	// Without a way to delegate, the mailbox of another user can't be managed
+	if _, err := config.gmailService("jane@domain.ext"); err == nil || !strings.Contains(err.Error(), "jane@domain.ext") {
+		t.Fatalf("expected an error about managing the mailbox of jane@domain.ext, got %v", err)
+	}
+}
+
+func TestConfigResolvedCustomerID(t *testing.T) {
+	requests := 0
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !strings.HasSuffix(r.URL.Path, "/users/admin@domain.ext") {
+			t.Errorf("expected the impersonated user to be fetched, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"customerId":"C01abc23d"}`)
+	})
+	config.ImpersonatedUserEmail = "admin@domain.ext"
+	config.CustomerId = "my_customer"
+	config.customerIDCache = &customerIDCache{}
+
+	for i := 0; i < 2; i++ {
+		customerID, err := config.resolvedCustomerID()
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		if customerID != "C01abc23d" {
+			t.Fatalf("expected customer ID C01abc23d, got %s", customerID)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected the customer ID to be cached, got %d requests", requests)
+	}
+
+	// Copies of the config share the resolved ID
+	copied := *config
+	if customerID, _ := copied.resolvedCustomerID(); customerID != "C01abc23d" || requests != 1 {
+		t.Fatalf("expected the cached customer ID in a copy, got %s after %d requests", customerID, requests)
+	}
+
+	// An explicit customer ID is used as is
+	config.CustomerId = "C99"
+	if customerID, _ := config.resolvedCustomerID(); customerID != "C99" || requests != 1 {
+		t.Fatalf("expected the configured customer ID, got %s after %d requests", customerID, requests)
+	}
+}
+
+func TestConfigImpersonating_cached(t *testing.T) {
+	config := Config{
+		Credentials:           testFakeCredentialsPath,
+		ImpersonatedUserEmail: "admin@domain.ext",
+		OauthScopes:           []string{"https://www.googleapis.com/auth/admin.directory.user"},
+	}
+
+	if err := config.loadAndValidate("0.12"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	// Don't reuse the clients cached by identical configs of other runs
+	config.subjectConfigs = &subjectConfigCache{configs: map[string]*Config{}}
+	config.subjectClients = &subjectClientCache{clients: map[string]*http.Client{}}
+
+	if c, err := config.impersonating("Admin@domain.ext"); err != nil || c != &config {
+		t.Fatalf("expected the config itself for the impersonated user, got %v", err)
+	}
+
+	var subjects []string
+	var scopes []string
+	subjectClient := config.subjectClient
+	config.subjectClient = func(s string, sc []string) (*http.Client, error) {
+		subjects, scopes = append(subjects, s), sc
+		return subjectClient(s, sc)
+	}
+
+	other, err := config.impersonating("other-admin@domain.ext")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if other == &config || other.directory == config.directory || other.ImpersonatedUserEmail != "other-admin@domain.ext" {
+		t.Fatalf("expected a separate config acting as other-admin@domain.ext")
+	}
+	if len(scopes) != 1 || scopes[0] != config.OauthScopes[0] {
+		t.Fatalf("expected the provider scopes, got %v", scopes)
+	}
+
+	cached, err := config.impersonating("Other-Admin@domain.ext")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if cached != other || len(subjects) != 1 {
+		t.Fatalf("expected the config to be cached, got %d clients for %v", len(subjects), subjects)
+	}
+
+	config.subjectClient = nil
+	config.subjectConfigs = nil
+	if _, err := config.impersonating("other-admin@domain.ext"); err == nil {
+		t.Fatalf("expected an error without credentials to impersonate with")
+	}
+}
+
+// testTokenServer fakes the OAuth 2.0 token endpoint and an API, and returns
+// credentials whose tokens are requested from it.
+func testTokenServer(t testing.TB) (*httptest.Server, string, *int32) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	credentials, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "terraform@project.iam.gserviceaccount.com",
+		"private_key":  string(privateKey),
+	})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var tokenRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/token" {
+			atomic.AddInt32(&tokenRequests, 1)
+			fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	return server, string(credentials), &tokenRequests
+}
+
+func testLoadAndRequest(t testing.TB, server *httptest.Server, credentials, subject string) *Config {
+	config := &Config{
+		Credentials:           credentials,
+		ImpersonatedUserEmail: subject,
+		OauthScopes:           []string{"https://www.googleapis.com/auth/admin.directory.user"},
+		TokenURL:              server.URL + "/token",
+	}
+	if err := config.loadAndValidate("0.12"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	resp, err := config.client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	resp.Body.Close()
+	return config
+}
+
+func TestConfigLoadAndValidate_sharedServices(t *testing.T) {
+	server, credentials, tokenRequests := testTokenServer(t)
+	defer server.Close()
+
+	first := testLoadAndRequest(t, server, credentials, "admin@domain.ext")
+	second := testLoadAndRequest(t, server, credentials, "admin@domain.ext")
+	if first.directory != second.directory || first.client != second.client {
+		t.Fatalf("expected identical configs to share their services")
+	}
+	if n := atomic.LoadInt32(tokenRequests); n != 1 {
+		t.Fatalf("expected a single token request, got %d", n)
+	}
+
+	other := testLoadAndRequest(t, server, credentials, "other-admin@domain.ext")
+	if other.directory == first.directory {
+		t.Fatalf("expected configs of another subject to have their own services")
+	}
+	if n := atomic.LoadInt32(tokenRequests); n != 2 {
+		t.Fatalf("expected a token request for the other subject, got %d", n)
+	}
+}
+
+// testSubjectTokenServer fakes the OAuth 2.0 token endpoint and an API, and
+// records the subject of every token request.
+func testSubjectTokenServer(t *testing.T) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	subjects := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/token" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+
+		var claims struct {
+			Sub string `json:"sub"`
+		}
+		parts := strings.Split(r.PostFormValue("assertion"), ".")
+		if len(parts) == 3 {
+			payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+			json.Unmarshal(payload, &claims)
+		}
+		mu.Lock()
+		subjects = append(subjects, claims.Sub)
+		mu.Unlock()
+		fmt.Fprint(w, `{"access_token":"token-`+claims.Sub+`","token_type":"Bearer","expires_in":3600}`)
+	}))
+	return server, &subjects
+}
+
+func TestConfigLoadAndValidate_separateSubjects(t *testing.T) {
+	server, subjects := testSubjectTokenServer(t)
+	defer server.Close()
+	keyServer, credentials, _ := testTokenServer(t)
+	keyServer.Close()
+
+	first := testLoadAndRequest(t, server, credentials, "admin@first.ext")
+	second := testLoadAndRequest(t, server, credentials, "admin@second.ext")
+
+	if first.client == second.client || first.directory == second.directory || first.delegation.tokenSource == second.delegation.tokenSource {
+		t.Fatalf("expected configs of other subjects to have their own clients and token sources")
+	}
+	if first.ImpersonatedUserEmail != "admin@first.ext" || second.ImpersonatedUserEmail != "admin@second.ext" {
+		t.Fatalf("expected each config to keep its own subject, got %s and %s", first.ImpersonatedUserEmail, second.ImpersonatedUserEmail)
+	}
+	if expected := []string{"admin@first.ext", "admin@second.ext"}; strings.Join(*subjects, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected tokens for %v, got %v", expected, *subjects)
+	}
+}
+
+func TestConfigLoadAndValidate_aliasSettings(t *testing.T) {
+	server, subjects := testSubjectTokenServer(t)
+	defer server.Close()
+	keyServer, credentials, _ := testTokenServer(t)
+	keyServer.Close()
+
+	load := func(customerID string) *Config {
+		config := &Config{
+			Credentials:           credentials,
+			ImpersonatedUserEmail: "admin@domain.ext",
+			CustomerId:            customerID,
+			OauthScopes:           []string{directory.AdminDirectoryUserScope},
+			TokenURL:              server.URL + "/token",
+		}
+		if err := config.loadAndValidate("0.12"); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		return config
+	}
+
+	first, second := load("C01"), load("C02")
+	if first.directory != second.directory {
+		t.Fatalf("expected identical credentials to share their services")
+	}
+	if first.customerDomainsCache == second.customerDomainsCache || first.subjectConfigs == second.subjectConfigs {
+		t.Fatalf("expected the caches depending on the provider settings not to be shared")
+	}
+
+	firstOther, err := first.impersonating("other@domain.ext")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	secondOther, err := second.impersonating("other@domain.ext")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if firstOther.CustomerId != "C01" || secondOther.CustomerId != "C02" {
+		t.Fatalf("expected the impersonating configs to keep the customer_id of their provider, got %s and %s", firstOther.CustomerId, secondOther.CustomerId)
+	}
+	if firstOther.client != secondOther.client {
+		t.Fatalf("expected the client of other@domain.ext to be shared")
+	}
+
+	resp, err := secondOther.client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	resp.Body.Close()
+	if strings.Join(*subjects, ",") != "other@domain.ext" {
+		t.Fatalf("expected a single token for other@domain.ext, got %v", *subjects)
+	}
+}
+
+func TestConfigLoadAndValidate_sharedServicesConcurrent(t *testing.T) {
+	server, credentials, _ := testTokenServer(t)
+	defer server.Close()
+
+	configs := make([]*Config, 10)
+	var wg sync.WaitGroup
+	for i := range configs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			configs[i] = &Config{
+				Credentials:           credentials,
+				ImpersonatedUserEmail: "admin@domain.ext",
+				TokenURL:              server.URL + "/token",
+			}
+			if err := configs[i].loadAndValidate("0.12"); err != nil {
+				t.Errorf("error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, config := range configs[1:] {
+		if config.directory != configs[0].directory {
+			t.Fatalf("expected concurrently loaded configs to share their services")
+		}
+	}
+}
+
+// testDelegationServer is a token endpoint whose domain-wide delegation only
+// grants the given scopes, like Google it refuses tokens for any other scope.
+func testDelegationServer(t testing.TB, granted ...string) (*httptest.Server, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	credentials, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "terraform@project.iam.gserviceaccount.com",
+		"client_id":    "123456789012345678901",
+		"private_key":  string(privateKey),
+	})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/token" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+
+		var claims struct {
+			Scope string `json:"scope"`
+		}
+		parts := strings.Split(r.PostFormValue("assertion"), ".")
+		if len(parts) == 3 {
+			payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+			json.Unmarshal(payload, &claims)
+		}
+
+		for _, scope := range strings.Fields(claims.Scope) {
+			authorized := false
+			for _, g := range granted {
+				authorized = authorized || g == scope
+			}
+			if !authorized {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"unauthorized_client","error_description":"Client is unauthorized to retrieve access tokens using this method, or client not authorized for any of the scopes requested."}`)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	return server, string(credentials)
+}
+
+func TestConfigLoadAndValidate_scopesChanged(t *testing.T) {
+	server, credentials := testDelegationServer(t, directory.AdminDirectoryGroupScope)
+	defer server.Close()
+
+	load := func(scopes ...string) *Config {
+		config := &Config{
+			Credentials:           credentials,
+			ImpersonatedUserEmail: "admin@domain.ext",
+			OauthScopes:           scopes,
+			TokenURL:              server.URL + "/token",
+		}
+		if err := config.loadAndValidate("0.12"); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		return config
+	}
+
+	first := load(directory.AdminDirectoryGroupScope)
+	if err := first.requireDelegation("gsuite_group", directory.AdminDirectoryGroupScope); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Adding a scope builds new services, which request it
+	second := load(directory.AdminDirectoryGroupScope, directory.AdminDirectoryUserScope)
+	if second.directory == first.directory || second.delegation == first.delegation {
+		t.Fatalf("expected a config with other scopes to have its own services")
+	}
+	if err := second.requireDelegation("gsuite_user", directory.AdminDirectoryUserScope); err == nil {
+		t.Fatal("expected an error for the scope the delegation does not grant")
+	}
+}
+
+func TestConfigRequireDelegation(t *testing.T) {
+	server, credentials := testDelegationServer(t, directory.AdminDirectoryGroupScope)
+	defer server.Close()
+
+	config := &Config{
+		Credentials:           credentials,
+		ImpersonatedUserEmail: "admin@domain.ext",
+		OauthScopes:           []string{directory.AdminDirectoryGroupScope, directory.AdminDirectoryUserScope},
+		TokenURL:              server.URL + "/token",
+	}
+	if err := config.loadAndValidate("0.12"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	cases := map[string]struct {
+		scopes   []string
+		expected string
+	}{
+		"missingScope": {
+			scopes:   []string{directory.AdminDirectoryUserScope},
+			expected: `[ERROR] gsuite_test requires the "https://www.googleapis.com/auth/admin.directory.user" oauth scope, which the domain-wide delegation of terraform@project.iam.gserviceaccount.com does not grant: authorize client ID 123456789012345678901 for the oauth scopes ["https://www.googleapis.com/auth/admin.directory.user"] in the Google Admin console under Security > API controls > Domain-wide delegation`,
+		},
+		"otherScope": {
+			scopes:   []string{directory.AdminDirectoryGroupScope},
+			expected: `[ERROR] gsuite_test cannot authenticate, the domain-wide delegation of terraform@project.iam.gserviceaccount.com does not grant the oauth scopes ["https://www.googleapis.com/auth/admin.directory.user"]: authorize client ID 123456789012345678901 for them in the Google Admin console under Security > API controls > Domain-wide delegation, or remove them from the oauth_scopes of the provider`,
+		},
+	}
+
+	for tn, tc := range cases {
+		err := config.requireDelegation("gsuite_test", tc.scopes...)
+		if err == nil {
+			t.Fatalf("%s: expected an error", tn)
+		}
+		if err.Error() != tc.expected {
+			t.Fatalf("%s: expected error %q, got %q", tn, tc.expected, err)
+		}
+	}
+}
+
+func TestDelegationError_noClientID(t *testing.T) {
+	err := delegationError("gsuite_test", nil, []string{directory.AdminDirectoryUserScope}, "", "terraform@project.iam.gserviceaccount.com")
+	if expected := "authorize the client ID of terraform@project.iam.gserviceaccount.com for them"; !strings.Contains(err.Error(), expected) {
+		t.Fatalf("expected error to contain %q, got %q", expected, err)
+	}
+}
+
+func TestTokenError(t *testing.T) {
+	retrieveError := func(status int, body string) error {
+		return &oauth2.RetrieveError{Response: &http.Response{StatusCode: status, Status: http.StatusText(status)}, Body: []byte(body)}
+	}
+	scopes := []string{directory.AdminDirectoryUserScope}
+
+	testCases := map[string]struct {
+		err      error
+		expected []string
+	}{
+		"invalidGrant": {
+			err:      retrieveError(400, `{"error":"invalid_grant","error_description":"Invalid JWT: Token must be a short-lived token (60 minutes) and in a reasonable timeframe. Check your iat and exp values in the JWT claim."}`),
+			expected: []string{"(invalid_grant: Invalid JWT", "clock", "client ID 123456789012345678901"},
+		},
+		"unauthorizedClient": {
+			err:      retrieveError(401, `{"error":"unauthorized_client","error_description":"Client is unauthorized to retrieve access tokens using this method, or client not authorized for any of the scopes requested."}`),
+			expected: []string{"(unauthorized_client: Client is unauthorized", "Domain-wide delegation", "client ID 123456789012345678901", directory.AdminDirectoryUserScope},
+		},
+		"accessDenied": {
+			err:      retrieveError(403, `{"error":"access_denied","error_description":"Requested client not authorized."}`),
+			expected: []string{"(access_denied: Requested client not authorized.)", "on behalf of admin@domain.ext", directory.AdminDirectoryUserScope},
+		},
+		"wrapped": {
+			err:      fmt.Errorf("Get https://admin.googleapis.com: %s", retrieveError(400, `{"error":"invalid_grant"}`)),
+			expected: []string{"(invalid_grant)"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		err := tokenError(tc.err, "123456789012345678901", "terraform@project.iam.gserviceaccount.com", "admin@domain.ext", scopes)
+		if err == nil {
+			t.Fatalf("%s: expected the error to be classified", tn)
+		}
+		for _, s := range tc.expected {
+			if !strings.Contains(err.Error(), s) {
+				t.Errorf("%s: expected the error to contain %q, got %q", tn, s, err)
+			}
+		}
+	}
+
+	for _, err := range []error{retrieveError(500, `{"error":"internal_failure"}`), fmt.Errorf("dial tcp: i/o timeout")} {
+		if classified := tokenError(err, "", "terraform@project.iam.gserviceaccount.com", "admin@domain.ext", scopes); classified != nil {
+			t.Errorf("expected %q not to be classified, got %q", err, classified)
+		}
+	}
+}
+
+func TestConfigRequireDelegation_invalidGrant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_grant","error_description":"Invalid JWT Signature."}`)
+	}))
+	defer server.Close()
+
+	// Only the key is needed, the token endpoint refuses it
+	delegationServer, credentials := testDelegationServer(t)
+	delegationServer.Close()
+
+	config := &Config{
+		Credentials:           credentials,
+		ImpersonatedUserEmail: "admin@domain.ext",
+		OauthScopes:           []string{directory.AdminDirectoryUserScope},
+		TokenURL:              server.URL + "/token",
+	}
+	if err := config.loadAndValidate("0.12"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	err := config.requireDelegation("gsuite_user", directory.AdminDirectoryUserScope)
+	if err == nil || !strings.Contains(err.Error(), "(invalid_grant: Invalid JWT Signature.)") {
+		t.Fatalf("expected an invalid_grant error, got %v", err)
+	}
+}
+
+func TestConfigRequireDelegation_unclassifiedError(t *testing.T) {
+	var tokenRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"internal_failure"}`)
+	}))
+	defer server.Close()
+
+	delegationServer, credentials := testDelegationServer(t)
+	delegationServer.Close()
+
+	config := &Config{
+		Credentials:           credentials,
+		ImpersonatedUserEmail: "admin@domain.ext",
+		OauthScopes:           []string{directory.AdminDirectoryUserScope},
+		TokenURL:              server.URL + "/token",
+	}
+	if err := config.loadAndValidate("0.12"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	// The check is left to the requests, and not repeated for every one of them
+	for i := 0; i < 3; i++ {
+		if err := config.requireDelegation("gsuite_user", directory.AdminDirectoryUserScope); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if requests := atomic.LoadInt32(&tokenRequests); requests != 1 {
+		t.Fatalf("expected the token to be requested once, got %d requests", requests)
+	}
+}
+
+// BenchmarkConfigLoadAndValidate reports the token requests of configuring
+// identical providers, e.g. many aliases, and making a request with each.
+func BenchmarkConfigLoadAndValidate(b *testing.B) {
+	server, credentials, tokenRequests := testTokenServer(b)
+	defer server.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		testLoadAndRequest(b, server, credentials, "admin@domain.ext")
+	}
+	b.ReportMetric(float64(atomic.LoadInt32(tokenRequests))/float64(b.N), "tokens/op")
+}
+
+func TestConfigBaseTransport_proxy(t *testing.T) {
+	config := &Config{ProxyURL: "http://proxy.domain.ext:3128"}
+	transport, err := config.baseTransport()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", "https://admin.googleapis.com/admin/directory/v1/users", nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if proxy == nil || proxy.String() != "http://proxy.domain.ext:3128" {
+		t.Fatalf("expected the configured proxy, got %v", proxy)
+	}
+}
+
+func TestConfigLoadAndValidate_proxy(t *testing.T) {
+	// The fake server acts as the proxy of requests to hosts which don't exist
+	server, credentials, tokenRequests := testTokenServer(t)
+	defer server.Close()
+
+	config := &Config{
+		Credentials:           credentials,
+		ImpersonatedUserEmail: "admin@domain.ext",
+		TokenURL:              "http://oauth2.invalid/token",
+		ProxyURL:              server.URL,
+	}
+	if err := config.loadAndValidate("0.12"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	resp, err := config.client.Get("http://admin.invalid/")
+	if err != nil {
+		t.Fatalf("expected the request to be sent through the proxy: %v", err)
+	}
+	resp.Body.Close()
+	if n := atomic.LoadInt32(tokenRequests); n != 1 {
+		t.Fatalf("expected the token to be requested through the proxy, got %d token requests", n)
+	}
+
+	config.ProxyURL = "ftp://proxy.domain.ext"
+	if err := config.loadAndValidate("0.12"); err == nil {
+		t.Fatalf("expected an invalid proxy_url to fail")
+	}
+}
+
+func TestConfigBaseTransport_caCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	// Without the CA certificate the certificate of the server isn't trusted
+	resp, err := (&http.Client{Transport: http.DefaultTransport}).Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected the certificate of the test server to be untrusted")
+	}
+
+	caCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	config := &Config{CACertificate: string(caCertificate)}
+	transport, err := config.baseTransport()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs == nil {
+		t.Fatalf("expected the CA certificate to be loaded into the root CAs")
+	}
+
+	resp, err = (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the CA certificate to be trusted: %v", err)
+	}
+	resp.Body.Close()
+
+	config.CACertificate = "-----BEGIN CERTIFICATE-----\nnot a certificate\n-----END CERTIFICATE-----\n"
+	if _, err := config.baseTransport(); err == nil || !strings.Contains(err.Error(), "ca_certificate") {
+		t.Fatalf("expected an error naming the ca_certificate, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package gsuite
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"golang.org/x/oauth2"
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// dataAuthCheck makes a cheap authenticated call with the provider config, to
+// confirm the credentials, the domain-wide delegation and the oauth scopes
+// work before a big apply.
+func dataAuthCheck() *schema.Resource {
+	return &schema.Resource{
+		Read: dataAuthCheckRead,
+		Schema: map[string]*schema.Schema{
+			"customer_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"impersonated_user_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"is_admin": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			// The configured scopes, all of them are granted once the check
+			// passes
+			"oauth_scopes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataAuthCheckRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if config.ImpersonatedUserEmail == "" {
+		return fmt.Errorf("[ERROR] gsuite_auth_check requires the impersonated_user_email of the provider, the Admin SDK is only accessible on behalf of an admin")
+	}
+
+	userScopes := []string{directory.AdminDirectoryUserScope, directory.AdminDirectoryUserReadonlyScope}
+	if err := config.requireScope("gsuite_auth_check", userScopes...); err != nil {
+		return err
+	}
+	if err := config.requireDelegation("gsuite_auth_check", userScopes...); err != nil {
+		return err
+	}
+
+	// Not retried, a failing check should fail fast and the retries would
+	// hide the 401 of refused credentials
+	user, err := config.directory.Users.Get(config.ImpersonatedUserEmail).Fields("primaryEmail", "customerId", "isAdmin").Do()
+	if err != nil {
+		return authCheckError(config, err)
+	}
+
+	scopes := append([]string{}, config.OauthScopes...)
+	sort.Strings(scopes)
+
+	d.SetId(user.CustomerId + "/" + strings.ToLower(user.PrimaryEmail))
+	d.Set("customer_id", user.CustomerId)
+	d.Set("impersonated_user_email", strings.ToLower(user.PrimaryEmail))
+	d.Set("is_admin", user.IsAdmin)
+	if err := d.Set("oauth_scopes", scopes); err != nil {
+		return fmt.Errorf("Error setting oauth_scopes in state: %s", err.Error())
+	}
+
+	return nil
+}
+
+// authCheckError tells refused credentials, a domain-wide delegation lacking
+// scopes and missing scopes or admin privileges apart, so the error says what
+// to fix.
+func authCheckError(config *Config, err error) error {
+	impersonated := config.ImpersonatedUserEmail
+
+	if isUnauthorizedClientError(err) {
+		if config.delegation != nil {
+			if missing, _ := config.delegation.unauthorizedScopes(); len(missing) > 0 {
+				return delegationError("gsuite_auth_check", nil, missing, config.delegation.clientID, config.delegation.clientEmail)
+			}
+		}
+		return fmt.Errorf("[ERROR] Delegation problem: the service account is not allowed to act on behalf of %s, authorize its client ID for the oauth_scopes of the provider in the Google Admin console under Security > API controls > Domain-wide delegation: %s", impersonated, err)
+	}
+
+	var rerr *oauth2.RetrieveError
+	if errors.As(err, &rerr) {
+		clientID, clientEmail := "", "the service account"
+		if config.delegation != nil {
+			clientID, clientEmail = config.delegation.clientID, config.delegation.clientEmail
+		}
+		if refused := tokenError(err, clientID, clientEmail, impersonated, config.OauthScopes); refused != nil {
+			problem := "Credential problem"
+			if code, _ := parseTokenError(err); code == "access_denied" {
+				problem = "Privilege problem"
+			}
+			return fmt.Errorf("[ERROR] %s: %s", problem, strings.TrimPrefix(refused.Error(), "[ERROR] "))
+		}
+		return fmt.Errorf("[ERROR] Credential problem: the token endpoint refused the credentials of the provider, make sure the service account key is valid and neither deleted nor disabled: %s", err)
+	}
+
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		switch {
+		case gerr.Code == http.StatusUnauthorized:
+			return fmt.Errorf("[ERROR] Credential problem: the access token of the provider was refused, make sure the credentials belong to a service account with domain-wide delegation: %s", err)
+		case gerr.Code == http.StatusForbidden && isInsufficientScopesError(gerr):
+			return fmt.Errorf("[ERROR] Scope problem: the access token does not carry a scope to read users, add %q to the oauth_scopes of the provider and to its domain-wide delegation: %s", directory.AdminDirectoryUserReadonlyScope, err)
+		case gerr.Code == http.StatusForbidden:
+			return fmt.Errorf("[ERROR] Privilege problem: %s is not allowed to read users, make sure it is an admin with the privileges the resources need: %s", impersonated, err)
+		}
+	}
+
+	return fmt.Errorf("[ERROR] Error checking the authentication of %s: %s", impersonated, err)
+}
+
+// isInsufficientScopesError reports whether the API refused a request because
+// the access token lacks the scope of the call.
+func isInsufficientScopesError(gerr *googleapi.Error) bool {
+	for _, e := range gerr.Errors {
+		if e.Reason == "insufficientPermissions" || e.Reason == "ACCESS_TOKEN_SCOPE_INSUFFICIENT" {
+			return true
+		}
+	}
+	return strings.Contains(gerr.Message, "insufficient authentication scopes")
+}
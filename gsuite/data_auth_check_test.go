@@ -0,0 +1,91 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"golang.org/x/oauth2"
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestDataAuthCheckRead(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/users/admin@domain.ext") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"primaryEmail":"Admin@domain.ext","customerId":"C0123abcd","isAdmin":true}`)
+	})
+	config.ImpersonatedUserEmail = "admin@domain.ext"
+	config.OauthScopes = []string{directory.AdminDirectoryUserScope, directory.AdminDirectoryGroupScope}
+
+	d := schema.TestResourceDataRaw(t, dataAuthCheck().Schema, map[string]interface{}{})
+	if err := dataAuthCheckRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Get("customer_id").(string) != "C0123abcd" || d.Get("impersonated_user_email").(string) != "admin@domain.ext" || !d.Get("is_admin").(bool) {
+		t.Fatalf("expected admin@domain.ext of C0123abcd, got %q of %q", d.Get("impersonated_user_email"), d.Get("customer_id"))
+	}
+	if scopes := d.Get("oauth_scopes").([]interface{}); len(scopes) != 2 || scopes[0] != directory.AdminDirectoryGroupScope {
+		t.Fatalf("expected the sorted oauth scopes, got %v", scopes)
+	}
+}
+
+func TestDataAuthCheckRead_missingScope(t *testing.T) {
+	config := &Config{
+		ImpersonatedUserEmail: "admin@domain.ext",
+		OauthScopes:           []string{directory.AdminDirectoryGroupScope},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataAuthCheck().Schema, map[string]interface{}{})
+	err := dataAuthCheckRead(d, config)
+	if err == nil || !strings.Contains(err.Error(), directory.AdminDirectoryUserScope) {
+		t.Fatalf("expected an error naming the user scope, got %v", err)
+	}
+}
+
+func TestAuthCheckError(t *testing.T) {
+	config := &Config{ImpersonatedUserEmail: "admin@domain.ext"}
+
+	testCases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"refusedKey": {
+			err:      &url.Error{Op: "Get", URL: "https://admin.googleapis.com", Err: &oauth2.RetrieveError{Response: &http.Response{StatusCode: 400}, Body: []byte(`{"error":"invalid_grant","error_description":"Invalid JWT Signature."}`)}},
+			expected: "Credential problem",
+		},
+		"refusedToken": {
+			err:      &googleapi.Error{Code: 401, Message: "Invalid Credentials"},
+			expected: "Credential problem",
+		},
+		"delegation": {
+			err:      &url.Error{Op: "Get", URL: "https://admin.googleapis.com", Err: &oauth2.RetrieveError{Response: &http.Response{StatusCode: 401}, Body: []byte(`{"error":"unauthorized_client"}`)}},
+			expected: "Delegation problem",
+		},
+		"insufficientScopes": {
+			err:      &googleapi.Error{Code: 403, Message: "Request had insufficient authentication scopes.", Errors: []googleapi.ErrorItem{{Reason: "insufficientPermissions"}}},
+			expected: "Scope problem",
+		},
+		"notAdmin": {
+			err:      &googleapi.Error{Code: 403, Message: "Not Authorized to access this resource/api", Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}},
+			expected: "Privilege problem: admin@domain.ext",
+		},
+		"other": {
+			err:      &googleapi.Error{Code: 500, Message: "Backend Error"},
+			expected: "Error checking the authentication of admin@domain.ext",
+		},
+	}
+
+	for tn, tc := range testCases {
+		if err := authCheckError(config, tc.err); !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: expected the error to contain %q, got %q", tn, tc.expected, err)
+		}
+	}
+}
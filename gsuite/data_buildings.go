@@ -0,0 +1,102 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataBuildings() *schema.Resource {
+	return &schema.Resource{
+		Read: dataBuildingsRead,
+		Schema: map[string]*schema.Schema{
+			"buildings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"building_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"building_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"floor_names": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"coordinates": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"latitude": {
+										Type:     schema.TypeFloat,
+										Computed: true,
+									},
+									"longitude": {
+										Type:     schema.TypeFloat,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataBuildingsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	result := []map[string]interface{}{}
+	err = paginate(func(token string) (string, error) {
+		var buildings *directory.Buildings
+		var err error
+		err = retry(func() error {
+			buildings, err = config.directory.Resources.Buildings.List(customerID).MaxResults(500).PageToken(token).Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return "", err
+		}
+
+		for _, building := range buildings.Buildings {
+			result = append(result, map[string]interface{}{
+				"building_id":   building.BuildingId,
+				"building_name": building.BuildingName,
+				"description":   building.Description,
+				"floor_names":   building.FloorNames,
+				"coordinates":   flattenBuildingCoordinates(building.Coordinates),
+			})
+		}
+		return buildings.NextPageToken, nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching buildings: %s", err)
+	}
+
+	d.SetId(customerID)
+	if err := d.Set("buildings", result); err != nil {
+		return fmt.Errorf("Error setting buildings in state: %s", err.Error())
+	}
+
+	return nil
+}
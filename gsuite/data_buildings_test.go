@@ -0,0 +1,55 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataBuildingsRead(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/customer/C123/resources/buildings") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("maxResults") != "500" {
+			t.Errorf("expected the maximum page size, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch q.Get("pageToken") {
+		case "":
+			fmt.Fprint(w, `{"buildings":[{"buildingId":"hq","buildingName":"Headquarters","floorNames":["B1","1"],"coordinates":{"latitude":51.2,"longitude":6.7}}],"nextPageToken":"page-2"}`)
+		case "page-2":
+			fmt.Fprint(w, `{"buildings":[{"buildingId":"annex","buildingName":"Annex","description":"Across the street","floorNames":["1"]}]}`)
+		default:
+			t.Errorf("unexpected page token: %q", q.Get("pageToken"))
+		}
+	})
+	config.CustomerId = "C123"
+
+	d := schema.TestResourceDataRaw(t, dataBuildings().Schema, map[string]interface{}{})
+	if err := dataBuildingsRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if n := d.Get("buildings.#").(int); n != 2 {
+		t.Fatalf("expected the buildings of both pages, got %d buildings", n)
+	}
+	expected := map[string]interface{}{
+		"buildings.0.building_id":            "hq",
+		"buildings.0.floor_names.1":          "1",
+		"buildings.0.coordinates.0.latitude": 51.2,
+		"buildings.1.building_name":          "Annex",
+		"buildings.1.description":            "Across the street",
+		"buildings.1.coordinates.#":          0,
+	}
+	for k, v := range expected {
+		if actual := d.Get(k); actual != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, actual)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataCalendarResource() *schema.Resource {
+	return &schema.Resource{
+		Read: dataCalendarResourceRead,
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"resource_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"resource_category": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"resource_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"user_visible_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"building_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"floor_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"floor_section": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"resource_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"generated_resource_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataCalendarResourceRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	resourceID := d.Get("resource_id").(string)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	var calendarResource *directory.CalendarResource
+	err = retry(func() error {
+		calendarResource, err = config.directory.Resources.Calendars.Get(customerID, resourceID).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("[ERROR] Calendar resource %s does not exist", resourceID)
+		}
+		return fmt.Errorf("[ERROR] Error fetching calendar resource %s: %s", resourceID, err)
+	}
+
+	d.SetId(calendarResource.ResourceId)
+	d.Set("resource_name", calendarResource.ResourceName)
+	d.Set("resource_type", calendarResource.ResourceType)
+	d.Set("resource_category", calendarResource.ResourceCategory)
+	d.Set("resource_description", calendarResource.ResourceDescription)
+	d.Set("user_visible_description", calendarResource.UserVisibleDescription)
+	d.Set("capacity", calendarResource.Capacity)
+	d.Set("building_id", calendarResource.BuildingId)
+	d.Set("floor_name", calendarResource.FloorName)
+	d.Set("floor_section", calendarResource.FloorSection)
+	d.Set("resource_email", calendarResource.ResourceEmail)
+	d.Set("generated_resource_name", calendarResource.GeneratedResourceName)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataCalendarResourceRead(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/customer/C0123abcd/resources/calendars/room-1"):
+			fmt.Fprint(w, `{"resourceId":"room-1","resourceName":"Room 1","resourceCategory":"CONFERENCE_ROOM","capacity":8,"buildingId":"headquarters","floorName":"1","resourceEmail":"c_room-1@resource.calendar.google.com"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":404,"message":"Resource Not Found: room-2"}}`)
+		}
+	})
+	config.CustomerId = "C0123abcd"
+
+	d := schema.TestResourceDataRaw(t, dataCalendarResource().Schema, map[string]interface{}{
+		"resource_id": "room-1",
+	})
+	if err := dataCalendarResourceRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"resource_name":     "Room 1",
+		"resource_category": "CONFERENCE_ROOM",
+		"capacity":          8,
+		"building_id":       "headquarters",
+		"floor_name":        "1",
+		"resource_email":    "c_room-1@resource.calendar.google.com",
+	}
+	if d.Id() != "room-1" {
+		t.Errorf("expected id room-1, got %s", d.Id())
+	}
+	for k, v := range expected {
+		if actual := d.Get(k); actual != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, actual)
+		}
+	}
+
+	d = schema.TestResourceDataRaw(t, dataCalendarResource().Schema, map[string]interface{}{
+		"resource_id": "room-2",
+	})
+	if err := dataCalendarResourceRead(d, config); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected an error about room-2 not existing, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataCalendarResources() *schema.Resource {
+	return &schema.Resource{
+		Read: dataCalendarResourcesRead,
+		Schema: map[string]*schema.Schema{
+			// Only list the calendar resources in this building
+			"building_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"calendar_resources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_visible_description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"capacity": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"building_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"floor_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"floor_section": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"generated_resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataCalendarResourcesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	buildingID := d.Get("building_id").(string)
+
+	result := []map[string]interface{}{}
+	err = paginate(func(token string) (string, error) {
+		var calendarResources *directory.CalendarResources
+		var err error
+		err = retry(func() error {
+			call := config.directory.Resources.Calendars.List(customerID).MaxResults(500).PageToken(token)
+			if buildingID != "" {
+				call = call.Query(fmt.Sprintf("buildingId=%s", buildingID))
+			}
+			calendarResources, err = call.Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return "", err
+		}
+
+		for _, calendarResource := range calendarResources.Items {
+			result = append(result, map[string]interface{}{
+				"resource_id":              calendarResource.ResourceId,
+				"resource_name":            calendarResource.ResourceName,
+				"resource_type":            calendarResource.ResourceType,
+				"resource_category":        calendarResource.ResourceCategory,
+				"user_visible_description": calendarResource.UserVisibleDescription,
+				"capacity":                 int(calendarResource.Capacity),
+				"building_id":              calendarResource.BuildingId,
+				"floor_name":               calendarResource.FloorName,
+				"floor_section":            calendarResource.FloorSection,
+				"resource_email":           calendarResource.ResourceEmail,
+				"generated_resource_name":  calendarResource.GeneratedResourceName,
+			})
+		}
+		return calendarResources.NextPageToken, nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching calendar resources: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", customerID, buildingID))
+	if err := d.Set("calendar_resources", result); err != nil {
+		return fmt.Errorf("Error setting calendar_resources in state: %s", err.Error())
+	}
+
+	return nil
+}
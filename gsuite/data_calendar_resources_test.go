@@ -0,0 +1,66 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataCalendarResourcesRead(t *testing.T) {
+	testCases := []struct {
+		buildingID string
+		query      string
+	}{
+		{"", ""},
+		{"hq", "buildingId=hq"},
+	}
+
+	for _, testCase := range testCases {
+		config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/customer/C123/resources/calendars") {
+				t.Errorf("unexpected path: %s", r.URL.Path)
+			}
+			q := r.URL.Query()
+			if q.Get("maxResults") != "500" || q.Get("query") != testCase.query {
+				t.Errorf("expected the maximum page size and query %q, got %s", testCase.query, r.URL.RawQuery)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			switch q.Get("pageToken") {
+			case "":
+				fmt.Fprint(w, `{"items":[{"resourceId":"room-1","resourceName":"Room 1","resourceCategory":"CONFERENCE_ROOM","capacity":8,"buildingId":"hq","floorName":"1","resourceEmail":"c_room-1@resource.calendar.google.com"}],"nextPageToken":"page-2"}`)
+			case "page-2":
+				fmt.Fprint(w, `{"items":[{"resourceId":"projector","resourceName":"Projector","resourceCategory":"OTHER","buildingId":"hq"}]}`)
+			default:
+				t.Errorf("unexpected page token: %q", q.Get("pageToken"))
+			}
+		})
+		config.CustomerId = "C123"
+
+		d := schema.TestResourceDataRaw(t, dataCalendarResources().Schema, map[string]interface{}{
+			"building_id": testCase.buildingID,
+		})
+		if err := dataCalendarResourcesRead(d, config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if n := d.Get("calendar_resources.#").(int); n != 2 {
+			t.Fatalf("expected the calendar resources of both pages, got %d calendar resources", n)
+		}
+		expected := map[string]interface{}{
+			"calendar_resources.0.resource_id":       "room-1",
+			"calendar_resources.0.capacity":          8,
+			"calendar_resources.0.resource_email":    "c_room-1@resource.calendar.google.com",
+			"calendar_resources.1.resource_category": "OTHER",
+			"calendar_resources.1.building_id":       "hq",
+		}
+		for k, v := range expected {
+			if actual := d.Get(k); actual != v {
+				t.Errorf("expected %s to be %v, got %v", k, v, actual)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataCustomer() *schema.Resource {
+	return &schema.Resource{
+		Read: dataCustomerRead,
+		Schema: map[string]*schema.Schema{
+			"customer_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// The primary domain of the customer
+			"customer_domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"alternate_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"phone_number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"language": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"customer_creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"postal_address": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"contact_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"organization_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address_line1": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address_line2": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address_line3": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"locality": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"postal_code": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"country_code": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataCustomerRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	// my_customer is resolved to the customer of the impersonated user
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	var customer *directory.Customer
+	err = retry(func() error {
+		customer, err = config.directory.Customers.Get(customerID).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching customer %s: %s", customerID, err)
+	}
+
+	d.SetId(customer.Id)
+	d.Set("customer_id", customer.Id)
+	d.Set("customer_domain", customer.CustomerDomain)
+	d.Set("alternate_email", customer.AlternateEmail)
+	d.Set("phone_number", customer.PhoneNumber)
+	d.Set("language", customer.Language)
+	d.Set("customer_creation_time", customer.CustomerCreationTime)
+	if err := d.Set("postal_address", flattenCustomerPostalAddress(customer.PostalAddress)); err != nil {
+		return fmt.Errorf("Error setting postal_address in state: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataCustomerRead(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		// my_customer is resolved through the impersonated user
+		case strings.HasSuffix(r.URL.Path, "/users/admin@domain.ext"):
+			fmt.Fprint(w, `{"customerId":"C0123abcd"}`)
+		case strings.HasSuffix(r.URL.Path, "/customers/C0123abcd"):
+			fmt.Fprint(w, `{"id":"C0123abcd","customerDomain":"domain.ext","alternateEmail":"it@other.ext","language":"en","postalAddress":{"organizationName":"Domain","countryCode":"DE"}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	config.CustomerId = "my_customer"
+	config.ImpersonatedUserEmail = "admin@domain.ext"
+
+	d := schema.TestResourceDataRaw(t, dataCustomer().Schema, map[string]interface{}{})
+	if err := dataCustomerRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Id() != "C0123abcd" || d.Get("customer_domain").(string) != "domain.ext" || d.Get("language").(string) != "en" {
+		t.Fatalf("expected customer C0123abcd of domain.ext, got %q and %q", d.Id(), d.Get("customer_domain"))
+	}
+	if got := d.Get("postal_address.0.country_code").(string); got != "DE" {
+		t.Fatalf("expected country code DE, got %q", got)
+	}
+	if got := d.Get("phone_number").(string); got != "" {
+		t.Fatalf("expected no phone number, got %q", got)
+	}
+}
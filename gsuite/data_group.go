@@ -12,8 +12,17 @@ func dataGroup() *schema.Resource {
 		Read: dataGroupRead,
 		Schema: map[string]*schema.Schema{
 			"email": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"email", "group_id"},
+			},
+
+			"group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"email", "group_id"},
 			},
 
 			"aliases": {
@@ -62,20 +71,34 @@ func dataGroup() *schema.Resource {
 func dataGroupRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
+	// The API accepts the group email, an alias or the unique group id
+	groupKey := d.Get("email").(string)
+	if v, ok := d.GetOk("group_id"); ok {
+		groupKey = v.(string)
+	}
+
 	var group *directory.Group
 	var err error
 	err = retry(func() error {
-		group, err = config.directory.Groups.Get(d.Get("email").(string)).Do()
+		group, err = config.directory.Groups.Get(groupKey).Do()
 		return err
 	}, config.TimeoutMinutes)
 
 	if err != nil {
-		return handleNotFoundError(err, d, fmt.Sprintf("Group %q", d.Get("name").(string)))
+		if isNotFound(err) {
+			return fmt.Errorf("[ERROR] Group %q does not exist", groupKey)
+		}
+		return fmt.Errorf("[ERROR] Error fetching group %q: %s", groupKey, err)
 	}
 
-	members, err := getAPIMembers(d.Get("email").(string), config)
+	members, err := getAPIMembers(group.Email, config)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching members of group %q: %s", group.Email, err)
+	}
 
 	d.SetId(group.Id)
+	d.Set("group_id", group.Id)
+	d.Set("email", group.Email)
 	d.Set("name", group.Name)
 	d.Set("description", group.Description)
 	d.Set("direct_members_count", group.DirectMembersCount)
@@ -0,0 +1,92 @@
+package gsuite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataGroupMembersRead,
+		Schema: map[string]*schema.Schema{
+			"group_email": {
+				Type:     schema.TypeString,
+				Required: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+
+			"roles": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"OWNER", "MANAGER", "MEMBER"}, false),
+				},
+			},
+
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataGroupMembersRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	groupEmail := strings.ToLower(d.Get("group_email").(string))
+	roles := convertStringSet(d.Get("roles").(*schema.Set))
+
+	members, err := listAPIMembers(groupEmail, strings.Join(roles, ","), config)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching members of group %q: %s", groupEmail, err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(members))
+	for _, member := range members {
+		result = append(result, map[string]interface{}{
+			"id":     member.Id,
+			"email":  strings.ToLower(member.Email),
+			"role":   member.Role,
+			"type":   member.Type,
+			"status": member.Status,
+		})
+	}
+
+	d.SetId(groupEmail)
+	if err := d.Set("members", result); err != nil {
+		return fmt.Errorf("Error setting members in state: %s", err.Error())
+	}
+
+	return nil
+}
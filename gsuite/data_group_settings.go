@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	groupSettings "google.golang.org/api/groupssettings/v1"
 )
 
 func dataGroupSettings() *schema.Resource {
@@ -250,7 +251,13 @@ func dataGroupSettings() *schema.Resource {
 func dataGroupSettingsRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
-	id, err := config.groupSettings.Groups.Get(d.Get("email").(string)).Do()
+	var id *groupSettings.Groups
+	var err error
+	err = retry(func() error {
+		id, err = config.groupSettings.Groups.Get(d.Get("email").(string)).Do()
+		return err
+	}, config.TimeoutMinutes)
+
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error fetching group settings. Make sure the group '%s' exists: %s ", d.Get("email").(string), err)
 	}
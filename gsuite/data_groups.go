@@ -0,0 +1,126 @@
+package gsuite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataGroups() *schema.Resource {
+	return &schema.Resource{
+		Read: dataGroupsRead,
+		Schema: map[string]*schema.Schema{
+			// Lists the groups of the whole customer when not set
+			"domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+
+			// See https://developers.google.com/admin-sdk/directory/v1/guides/search-groups
+			"query": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"direct_members_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"admin_created": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// listAPIGroupsPages calls f with every page of groups of the customer, or of
+// the domain when one is given.
+func listAPIGroupsPages(domain, query string, config *Config, f func([]*directory.Group) error) error {
+	return paginate(func(token string) (string, error) {
+		var response *directory.Groups
+		var err error
+		err = retry(func() error {
+			call := config.directory.Groups.List().MaxResults(200).PageToken(token)
+			if domain != "" {
+				call = call.Domain(domain)
+			} else {
+				call = call.Customer(config.CustomerId)
+			}
+			if query != "" {
+				call = call.Query(query)
+			}
+			response, err = call.Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return "", err
+		}
+		return response.NextPageToken, f(response.Groups)
+	})
+}
+
+func dataGroupsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	domain := strings.ToLower(d.Get("domain").(string))
+	query := d.Get("query").(string)
+
+	var result []map[string]interface{}
+	err := listAPIGroupsPages(domain, query, config, func(groups []*directory.Group) error {
+		for _, group := range groups {
+			result = append(result, map[string]interface{}{
+				"id":                   group.Id,
+				"email":                group.Email,
+				"name":                 group.Name,
+				"description":          group.Description,
+				"direct_members_count": int(group.DirectMembersCount),
+				"admin_created":        group.AdminCreated,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching groups: %s", err)
+	}
+
+	scope := domain
+	if scope == "" {
+		scope = config.CustomerId
+	}
+	d.SetId(fmt.Sprintf("%s/%s", scope, query))
+	if err := d.Set("groups", result); err != nil {
+		return fmt.Errorf("Error setting groups in state: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataGroupsRead(t *testing.T) {
+	testCases := []struct {
+		domain   string
+		customer string
+	}{
+		{"", "C123"},
+		{"domain.ext", ""},
+	}
+
+	for _, testCase := range testCases {
+		config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("customer") != testCase.customer || q.Get("domain") != testCase.domain {
+				t.Errorf("expected customer %q and domain %q, got %s", testCase.customer, testCase.domain, r.URL.RawQuery)
+			}
+			if q.Get("query") != "email:team*" {
+				t.Errorf("unexpected query: %q", q.Get("query"))
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			switch q.Get("pageToken") {
+			case "":
+				fmt.Fprint(w, `{"groups":[{"id":"1","email":"team-a@domain.ext","name":"Team A","directMembersCount":"3"}],"nextPageToken":"page-2"}`)
+			case "page-2":
+				fmt.Fprint(w, `{"groups":[{"id":"2","email":"team-b@domain.ext","name":"Team B","description":"B","adminCreated":true}]}`)
+			default:
+				t.Errorf("unexpected page token: %q", q.Get("pageToken"))
+			}
+		})
+		config.CustomerId = "C123"
+
+		d := schema.TestResourceDataRaw(t, dataGroups().Schema, map[string]interface{}{
+			"domain": testCase.domain,
+			"query":  "email:team*",
+		})
+		if err := dataGroupsRead(d, config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if n := d.Get("groups.#").(int); n != 2 {
+			t.Fatalf("expected the groups of both pages, got %d groups", n)
+		}
+		if v := d.Get("groups.0.direct_members_count").(int); v != 3 {
+			t.Errorf("expected groups.0.direct_members_count to be 3, got %d", v)
+		}
+		if v := d.Get("groups.1.email").(string); v != "team-b@domain.ext" {
+			t.Errorf("expected groups.1.email to be team-b@domain.ext, got %q", v)
+		}
+		if v := d.Get("groups.1.admin_created").(bool); !v {
+			t.Errorf("expected groups.1.admin_created to be true")
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package gsuite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	reports "google.golang.org/api/admin/reports/v1"
+)
+
+func dataLoginActivities() *schema.Resource {
+	return &schema.Resource{
+		Read: dataLoginActivitiesRead,
+		Schema: map[string]*schema.Schema{
+			// The user, by email or id, or all users
+			"user_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "all",
+			},
+
+			"start_time": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			// Defaults to the time of the read
+			"end_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			// Only return events with this name, e.g. login_failure
+			"event_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor_email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataLoginActivitiesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userKey := strings.ToLower(d.Get("user_key").(string))
+	startTime := d.Get("start_time").(string)
+	endTime := d.Get("end_time").(string)
+	eventName := d.Get("event_name").(string)
+
+	result := []map[string]interface{}{}
+	err := paginate(func(token string) (string, error) {
+		var activities *reports.Activities
+		var err error
+		err = retry(func() error {
+			call := config.reports.Activities.List(userKey, "login").StartTime(startTime).MaxResults(1000).PageToken(token)
+			if endTime != "" {
+				call = call.EndTime(endTime)
+			}
+			if eventName != "" {
+				call = call.EventName(eventName)
+			}
+			activities, err = call.Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return "", err
+		}
+
+		// An activity holds one or more events which happened at once
+		for _, activity := range activities.Items {
+			actorEmail := ""
+			if activity.Actor != nil {
+				actorEmail = activity.Actor.Email
+			}
+			activityTime := ""
+			if activity.Id != nil {
+				activityTime = activity.Id.Time
+			}
+
+			for _, event := range activity.Events {
+				if eventName != "" && event.Name != eventName {
+					continue
+				}
+				result = append(result, map[string]interface{}{
+					"time":        activityTime,
+					"actor_email": actorEmail,
+					"event_name":  event.Name,
+					"event_type":  event.Type,
+					"ip_address":  activity.IpAddress,
+				})
+			}
+		}
+		return activities.NextPageToken, nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching login activities: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s", userKey, startTime, endTime, eventName))
+	if err := d.Set("events", result); err != nil {
+		return fmt.Errorf("Error setting events in state: %s", err.Error())
+	}
+
+	return nil
+}
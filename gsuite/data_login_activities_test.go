@@ -0,0 +1,60 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataLoginActivitiesRead(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/activity/users/all/applications/login") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("startTime") != "2021-04-01T00:00:00Z" || q.Get("endTime") != "2021-04-02T00:00:00Z" || q.Get("eventName") != "" {
+			t.Errorf("expected the time window without an event name, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch q.Get("pageToken") {
+		case "":
+			fmt.Fprint(w, `{"items":[{"id":{"time":"2021-04-01T08:00:00.000Z","applicationName":"login"},"actor":{"email":"jane@domain.ext"},"ipAddress":"192.0.2.1","events":[{"type":"login","name":"login_success"}]}],"nextPageToken":"page-2"}`)
+		case "page-2":
+			fmt.Fprint(w, `{"items":[{"id":{"time":"2021-04-01T09:00:00.000Z","applicationName":"login"},"actor":{"email":"john@domain.ext"},"ipAddress":"192.0.2.2","events":[{"type":"login","name":"login_failure"},{"type":"login","name":"login_challenge"}]}]}`)
+		default:
+			t.Errorf("unexpected page token: %q", q.Get("pageToken"))
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataLoginActivities().Schema, map[string]interface{}{
+		"start_time": "2021-04-01T00:00:00Z",
+		"end_time":   "2021-04-02T00:00:00Z",
+	})
+	if err := dataLoginActivitiesRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Every event of an activity is returned on its own
+	if n := d.Get("events.#").(int); n != 3 {
+		t.Fatalf("expected the events of both pages, got %d events", n)
+	}
+	expected := map[string]interface{}{
+		"events.0.time":        "2021-04-01T08:00:00.000Z",
+		"events.0.actor_email": "jane@domain.ext",
+		"events.0.event_name":  "login_success",
+		"events.0.ip_address":  "192.0.2.1",
+		"events.1.event_name":  "login_failure",
+		"events.2.actor_email": "john@domain.ext",
+		"events.2.event_name":  "login_challenge",
+		"events.2.event_type":  "login",
+	}
+	for k, v := range expected {
+		if actual := d.Get(k); actual != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, actual)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package gsuite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataMobileDevices() *schema.Resource {
+	return &schema.Resource{
+		Read: dataMobileDevicesRead,
+		Schema: map[string]*schema.Schema{
+			"user_email": {
+				Type:     schema.TypeString,
+				Required: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			"devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"serial_number": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_sync": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func listAPIMobileDevices(query string, config *Config) ([]*directory.MobileDevice, error) {
+	var devices []*directory.MobileDevice
+	err := paginate(func(token string) (string, error) {
+		var response *directory.MobileDevices
+		var err error
+		err = retry(func() error {
+			response, err = config.directory.Mobiledevices.List(config.CustomerId).Query(query).Projection("BASIC").MaxResults(100).PageToken(token).Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return "", err
+		}
+		devices = append(devices, response.Mobiledevices...)
+		return response.NextPageToken, nil
+	})
+	return devices, err
+}
+
+func dataMobileDevicesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userEmail := strings.ToLower(d.Get("user_email").(string))
+
+	devices, err := listAPIMobileDevices(fmt.Sprintf("email:%s", userEmail), config)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching mobile devices of user %q: %s", userEmail, err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(devices))
+	for _, device := range devices {
+		result = append(result, map[string]interface{}{
+			"resource_id":   device.ResourceId,
+			"device_id":     device.DeviceId,
+			"model":         device.Model,
+			"os":            device.Os,
+			"type":          device.Type,
+			"status":        device.Status,
+			"serial_number": device.SerialNumber,
+			"last_sync":     device.LastSync,
+		})
+	}
+
+	d.SetId(userEmail)
+	if err := d.Set("devices", result); err != nil {
+		return fmt.Errorf("Error setting devices in state: %s", err.Error())
+	}
+
+	return nil
+}
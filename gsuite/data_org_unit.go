@@ -0,0 +1,93 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataOrgUnit() *schema.Resource {
+	return &schema.Resource{
+		Read: dataOrgUnitRead,
+		Schema: map[string]*schema.Schema{
+			"org_unit_path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				StateFunc:    orgUnitPathStateFunc,
+				ValidateFunc: validateOrgUnitPath,
+			},
+
+			"org_unit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"parent_org_unit_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"parent_org_unit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"block_inheritance": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataOrgUnitRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	orgUnitPath := normalizeOrgUnitPath(d.Get("org_unit_path").(string))
+
+	// The root org unit has no path to look it up by, only children have
+	key := orgUnitKey(orgUnitPath)
+	if key == "" {
+		return fmt.Errorf("[ERROR] The root org unit / can't be looked up, only the org units below it")
+	}
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	var orgUnit *directory.OrgUnit
+	err = retry(func() error {
+		orgUnit, err = config.directory.Orgunits.Get(customerID, key).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("[ERROR] Org unit %s does not exist", orgUnitPath)
+		}
+		return fmt.Errorf("[ERROR] Error fetching org unit %s: %s", orgUnitPath, err)
+	}
+
+	d.SetId(orgUnit.OrgUnitId)
+	d.Set("org_unit_path", orgUnit.OrgUnitPath)
+	d.Set("org_unit_id", orgUnit.OrgUnitId)
+	d.Set("name", orgUnit.Name)
+	d.Set("description", orgUnit.Description)
+	d.Set("parent_org_unit_path", orgUnit.ParentOrgUnitPath)
+	d.Set("parent_org_unit_id", orgUnit.ParentOrgUnitId)
+	d.Set("block_inheritance", orgUnit.BlockInheritance)
+
+	return nil
+}
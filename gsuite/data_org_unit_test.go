@@ -0,0 +1,61 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataOrgUnitRead(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		// my_customer is resolved through the impersonated user
+		case strings.HasSuffix(r.URL.Path, "/users/admin@domain.ext"):
+			fmt.Fprint(w, `{"customerId":"C0123abcd"}`)
+		case strings.HasSuffix(r.URL.Path, "/customer/C0123abcd/orgunits/Engineering/Back End"):
+			fmt.Fprint(w, `{"orgUnitId":"id:03ph8a2z1","orgUnitPath":"/Engineering/Back End","name":"Back End","parentOrgUnitPath":"/Engineering","parentOrgUnitId":"id:03ph8a2z0","blockInheritance":true}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":404,"message":"Org unit not found"}}`)
+		}
+	})
+	config.CustomerId = "my_customer"
+	config.ImpersonatedUserEmail = "admin@domain.ext"
+
+	d := schema.TestResourceDataRaw(t, dataOrgUnit().Schema, map[string]interface{}{
+		"org_unit_path": "Engineering/Back End",
+	})
+	if err := dataOrgUnitRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"org_unit_id":          "id:03ph8a2z1",
+		"org_unit_path":        "/Engineering/Back End",
+		"name":                 "Back End",
+		"parent_org_unit_path": "/Engineering",
+		"block_inheritance":    true,
+	}
+	for k, v := range expected {
+		if actual := d.Get(k); actual != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, actual)
+		}
+	}
+
+	testCases := map[string]string{
+		"/Missing": "does not exist",
+		"/":        "root org unit",
+	}
+	for path, expectedErr := range testCases {
+		d = schema.TestResourceDataRaw(t, dataOrgUnit().Schema, map[string]interface{}{
+			"org_unit_path": path,
+		})
+		if err := dataOrgUnitRead(d, config); err == nil || !strings.Contains(err.Error(), expectedErr) {
+			t.Errorf("expected an error about %s for %s, got %v", expectedErr, path, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package gsuite
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataOrgUnits() *schema.Resource {
+	return &schema.Resource{
+		Read: dataOrgUnitsRead,
+		Schema: map[string]*schema.Schema{
+			// All org units below this path are listed, at any depth
+			"org_unit_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "/",
+				StateFunc:    orgUnitPathStateFunc,
+				ValidateFunc: validateOrgUnitPath,
+			},
+
+			// Only keep the direct children of this path
+			"parent_org_unit_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				StateFunc:    orgUnitPathStateFunc,
+				ValidateFunc: validateOrgUnitPath,
+			},
+
+			"org_units": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"org_unit_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"org_unit_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_org_unit_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_org_unit_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"block_inheritance": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataOrgUnitsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	orgUnitPath := normalizeOrgUnitPath(d.Get("org_unit_path").(string))
+	parentOrgUnitPath := normalizeOrgUnitPath(d.Get("parent_org_unit_path").(string))
+
+	// The org units are returned in a single response, there are no pages
+	var orgUnits *directory.OrgUnits
+	err = retry(func() error {
+		orgUnits, err = config.directory.Orgunits.List(customerID).OrgUnitPath(orgUnitPath).Type("all").Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching org units below %s: %s", orgUnitPath, err)
+	}
+
+	// Sorted by path, parents come before their children
+	sort.Slice(orgUnits.OrganizationUnits, func(i, j int) bool {
+		return orgUnits.OrganizationUnits[i].OrgUnitPath < orgUnits.OrganizationUnits[j].OrgUnitPath
+	})
+
+	result := []map[string]interface{}{}
+	for _, orgUnit := range orgUnits.OrganizationUnits {
+		if parentOrgUnitPath != "" && !strings.EqualFold(orgUnit.ParentOrgUnitPath, parentOrgUnitPath) {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"org_unit_id":          orgUnit.OrgUnitId,
+			"org_unit_path":        orgUnit.OrgUnitPath,
+			"name":                 orgUnit.Name,
+			"description":          orgUnit.Description,
+			"parent_org_unit_id":   orgUnit.ParentOrgUnitId,
+			"parent_org_unit_path": orgUnit.ParentOrgUnitPath,
+			"block_inheritance":    orgUnit.BlockInheritance,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s%s", customerID, orgUnitPath))
+	if err := d.Set("org_units", result); err != nil {
+		return fmt.Errorf("Error setting org_units in state: %s", err.Error())
+	}
+
+	return nil
+}
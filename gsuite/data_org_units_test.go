@@ -0,0 +1,54 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataOrgUnitsRead(t *testing.T) {
+	testCases := []struct {
+		config   map[string]interface{}
+		expected []string
+	}{
+		{map[string]interface{}{}, []string{"/Engineering", "/Engineering/Back End", "/Engineering/Back End/Oncall", "/Sales"}},
+		{map[string]interface{}{"parent_org_unit_path": "Engineering"}, []string{"/Engineering/Back End"}},
+	}
+
+	for _, testCase := range testCases {
+		config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/customer/C123/orgunits") {
+				t.Errorf("unexpected request %s", r.URL.Path)
+			}
+			if q := r.URL.Query(); q.Get("type") != "all" || q.Get("orgUnitPath") != "/" {
+				t.Errorf("expected all org units below /, got %s", r.URL.RawQuery)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"organizationUnits":[
+				{"orgUnitId":"id:sales","orgUnitPath":"/Sales","name":"Sales","parentOrgUnitId":"id:root","parentOrgUnitPath":"/"},
+				{"orgUnitId":"id:oncall","orgUnitPath":"/Engineering/Back End/Oncall","name":"Oncall","parentOrgUnitId":"id:backend","parentOrgUnitPath":"/Engineering/Back End"},
+				{"orgUnitId":"id:engineering","orgUnitPath":"/Engineering","name":"Engineering","parentOrgUnitId":"id:root","parentOrgUnitPath":"/"},
+				{"orgUnitId":"id:backend","orgUnitPath":"/Engineering/Back End","name":"Back End","parentOrgUnitId":"id:engineering","parentOrgUnitPath":"/Engineering","blockInheritance":true}
+			]}`)
+		})
+		config.CustomerId = "C123"
+
+		d := schema.TestResourceDataRaw(t, dataOrgUnits().Schema, testCase.config)
+		if err := dataOrgUnitsRead(d, config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		paths := []string{}
+		for _, orgUnit := range d.Get("org_units").([]interface{}) {
+			paths = append(paths, orgUnit.(map[string]interface{})["org_unit_path"].(string))
+		}
+		if !reflect.DeepEqual(paths, testCase.expected) {
+			t.Errorf("expected org units %v, got %v", testCase.expected, paths)
+		}
+	}
+}
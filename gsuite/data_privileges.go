@@ -0,0 +1,78 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataPrivileges() *schema.Resource {
+	return &schema.Resource{
+		Read: dataPrivilegesRead,
+		Schema: map[string]*schema.Schema{
+			"privileges": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"privilege_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_ou_scopable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenPrivileges walks the privilege tree, child privileges are returned
+// as regular entries since they can be granted on their own.
+func flattenPrivileges(privileges []*directory.Privilege) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(privileges))
+	for _, privilege := range privileges {
+		result = append(result, map[string]interface{}{
+			"service_id":     privilege.ServiceId,
+			"service_name":   privilege.ServiceName,
+			"privilege_name": privilege.PrivilegeName,
+			"is_ou_scopable": privilege.IsOuScopable,
+		})
+		result = append(result, flattenPrivileges(privilege.ChildPrivileges)...)
+	}
+	return result
+}
+
+func dataPrivilegesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var privileges *directory.Privileges
+	var err error
+	err = retry(func() error {
+		privileges, err = config.directory.Privileges.List(config.CustomerId).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing privileges: %s", err)
+	}
+
+	d.SetId(config.CustomerId)
+	if err := d.Set("privileges", flattenPrivileges(privileges.Items)); err != nil {
+		return fmt.Errorf("Error setting privileges in state: %s", err.Error())
+	}
+
+	return nil
+}
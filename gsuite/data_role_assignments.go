@@ -0,0 +1,117 @@
+package gsuite
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataRoleAssignments() *schema.Resource {
+	return &schema.Resource{
+		Read: dataRoleAssignmentsRead,
+		Schema: map[string]*schema.Schema{
+			// Only list the assignments of this role
+			"role_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Only list the assignments of this user, by email, alias or id
+			"user_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"role_assignments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_assignment_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"assigned_to": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scope_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"org_unit_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// listAPIRoleAssignmentsPages calls f with every page of role assignments of
+// the customer, optionally only those of a role or a user.
+func listAPIRoleAssignmentsPages(customerID, roleID, userKey string, config *Config, f func([]*directory.RoleAssignment) error) error {
+	return paginate(func(token string) (string, error) {
+		var response *directory.RoleAssignments
+		var err error
+		err = retry(func() error {
+			call := config.directory.RoleAssignments.List(customerID).MaxResults(200).PageToken(token)
+			if roleID != "" {
+				call = call.RoleId(roleID)
+			}
+			if userKey != "" {
+				call = call.UserKey(userKey)
+			}
+			response, err = call.Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return "", err
+		}
+		return response.NextPageToken, f(response.Items)
+	})
+}
+
+func dataRoleAssignmentsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	roleID := d.Get("role_id").(string)
+	userKey := d.Get("user_key").(string)
+
+	var result []map[string]interface{}
+	err = listAPIRoleAssignmentsPages(customerID, roleID, userKey, config, func(roleAssignments []*directory.RoleAssignment) error {
+		for _, roleAssignment := range roleAssignments {
+			result = append(result, map[string]interface{}{
+				"role_assignment_id": strconv.FormatInt(roleAssignment.RoleAssignmentId, 10),
+				"role_id":            strconv.FormatInt(roleAssignment.RoleId, 10),
+				"assigned_to":        roleAssignment.AssignedTo,
+				"scope_type":         roleAssignment.ScopeType,
+				"org_unit_id":        roleAssignment.OrgUnitId,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching role assignments: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", customerID, roleID, userKey))
+	if err := d.Set("role_assignments", result); err != nil {
+		return fmt.Errorf("Error setting role_assignments in state: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataRoleAssignmentsRead_paginated(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		// The customer ID is resolved from the impersonated user
+		if strings.HasSuffix(r.URL.Path, "/users/admin@domain.ext") {
+			fmt.Fprint(w, `{"customerId":"C123"}`)
+			return
+		}
+
+		if !strings.HasSuffix(r.URL.Path, "/customer/C123/roleassignments") {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("roleId") != "42" || q.Get("userKey") != "" {
+			t.Errorf("expected to filter on the role only, got %s", r.URL.RawQuery)
+		}
+
+		switch q.Get("pageToken") {
+		case "":
+			fmt.Fprint(w, `{"items":[{"roleAssignmentId":"1","roleId":"42","assignedTo":"100","scopeType":"CUSTOMER"}],"nextPageToken":"page-2"}`)
+		case "page-2":
+			fmt.Fprint(w, `{"items":[{"roleAssignmentId":"2","roleId":"42","assignedTo":"200","scopeType":"ORG_UNIT","orgUnitId":"03ph8a2z1enx4lx"}]}`)
+		default:
+			t.Errorf("unexpected page token: %q", q.Get("pageToken"))
+		}
+	})
+	config.CustomerId = "my_customer"
+	config.ImpersonatedUserEmail = "admin@domain.ext"
+
+	d := schema.TestResourceDataRaw(t, dataRoleAssignments().Schema, map[string]interface{}{
+		"role_id": "42",
+	})
+	if err := dataRoleAssignmentsRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if n := d.Get("role_assignments.#").(int); n != 2 {
+		t.Fatalf("expected the role assignments of both pages, got %d", n)
+	}
+	expected := map[string]string{
+		"role_assignments.0.role_assignment_id": "1",
+		"role_assignments.0.assigned_to":        "100",
+		"role_assignments.0.scope_type":         "CUSTOMER",
+		"role_assignments.1.role_id":            "42",
+		"role_assignments.1.assigned_to":        "200",
+		"role_assignments.1.scope_type":         "ORG_UNIT",
+		"role_assignments.1.org_unit_id":        "03ph8a2z1enx4lx",
+	}
+	for k, v := range expected {
+		if actual := d.Get(k).(string); actual != v {
+			t.Errorf("expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+	if d.Id() != "C123/42/" {
+		t.Errorf("unexpected id %q", d.Id())
+	}
+}
@@ -0,0 +1,106 @@
+package gsuite
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataRoles() *schema.Resource {
+	return &schema.Resource{
+		Read: dataRolesRead,
+		Schema: map[string]*schema.Schema{
+			// Built-in roles like _SEED_ADMIN_ROLE are system roles
+			"include_system_roles": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role_description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_system_role": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_super_admin_role": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// listAPIRolesPages calls f with every page of roles of the customer.
+func listAPIRolesPages(customerID string, config *Config, f func([]*directory.Role) error) error {
+	return paginate(func(token string) (string, error) {
+		var response *directory.Roles
+		var err error
+		err = retry(func() error {
+			response, err = config.directory.Roles.List(customerID).MaxResults(100).PageToken(token).Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return "", err
+		}
+		return response.NextPageToken, f(response.Items)
+	})
+}
+
+func dataRolesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	includeSystemRoles := d.Get("include_system_roles").(bool)
+
+	var result []map[string]interface{}
+	err = listAPIRolesPages(customerID, config, func(roles []*directory.Role) error {
+		for _, role := range roles {
+			if role.IsSystemRole && !includeSystemRoles {
+				continue
+			}
+			result = append(result, map[string]interface{}{
+				"role_id":             strconv.FormatInt(role.RoleId, 10),
+				"role_name":           role.RoleName,
+				"role_description":    role.RoleDescription,
+				"is_system_role":      role.IsSystemRole,
+				"is_super_admin_role": role.IsSuperAdminRole,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching roles: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%t", customerID, includeSystemRoles))
+	if err := d.Set("roles", result); err != nil {
+		return fmt.Errorf("Error setting roles in state: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataRolesRead(t *testing.T) {
+	testCases := []struct {
+		includeSystemRoles bool
+		expected           []string
+	}{
+		{true, []string{"_SEED_ADMIN_ROLE", "Helpdesk", "_GROUPS_ADMIN_ROLE"}},
+		{false, []string{"Helpdesk"}},
+	}
+
+	for _, testCase := range testCases {
+		config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/customer/C123/roles") {
+				t.Errorf("unexpected request %s", r.URL.Path)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			switch token := r.URL.Query().Get("pageToken"); token {
+			case "":
+				fmt.Fprint(w, `{"items":[{"roleId":"1","roleName":"_SEED_ADMIN_ROLE","isSystemRole":true,"isSuperAdminRole":true},{"roleId":"2","roleName":"Helpdesk","roleDescription":"Resets passwords"}],"nextPageToken":"page-2"}`)
+			case "page-2":
+				fmt.Fprint(w, `{"items":[{"roleId":"3","roleName":"_GROUPS_ADMIN_ROLE","isSystemRole":true}]}`)
+			default:
+				t.Errorf("unexpected page token: %q", token)
+			}
+		})
+		config.CustomerId = "C123"
+
+		d := schema.TestResourceDataRaw(t, dataRoles().Schema, map[string]interface{}{
+			"include_system_roles": testCase.includeSystemRoles,
+		})
+		if err := dataRolesRead(d, config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		roles := d.Get("roles").([]interface{})
+		names := []string{}
+		for _, role := range roles {
+			names = append(names, role.(map[string]interface{})["role_name"].(string))
+		}
+		if strings.Join(names, ",") != strings.Join(testCase.expected, ",") {
+			t.Errorf("expected roles %v, got %v", testCase.expected, names)
+		}
+	}
+}
+
+func TestDataRolesRead_flags(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"items":[{"roleId":"1","roleName":"_SEED_ADMIN_ROLE","isSystemRole":true,"isSuperAdminRole":true}]}`)
+	})
+	config.CustomerId = "C123"
+
+	d := schema.TestResourceDataRaw(t, dataRoles().Schema, map[string]interface{}{})
+	if err := dataRolesRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Get("roles.0.role_id").(string) != "1" || !d.Get("roles.0.is_system_role").(bool) || !d.Get("roles.0.is_super_admin_role").(bool) {
+		t.Errorf("expected the role and its flags to be read, got %v", d.Get("roles"))
+	}
+}
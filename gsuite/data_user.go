@@ -11,7 +11,7 @@ import (
 func dataUser() *schema.Resource {
 	return &schema.Resource{
 		Read: dataUserRead,
-		Schema: map[string]*schema.Schema{
+		Schema: mergeSchemas(map[string]*schema.Schema{
 			"primary_email": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -61,11 +61,17 @@ func dataUser() *schema.Resource {
 				Computed: true,
 			},
 
-			"include_in_global_list": {
+			"include_in_global_address_list": {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
 
+			"include_in_global_list": {
+				Type:       schema.TypeBool,
+				Computed:   true,
+				Deprecated: "Use include_in_global_address_list instead",
+			},
+
 			"is_ip_whitelisted": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -91,6 +97,21 @@ func dataUser() *schema.Resource {
 				Computed: true,
 			},
 
+			"ip_whitelisted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"is_enforced_in_2sv": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"is_enrolled_in_2sv": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
 			"is_mailbox_setup": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -313,22 +334,27 @@ func dataUser() *schema.Resource {
 					},
 				},
 			},
-		},
+		}, schemaUserRead),
 	}
 }
 
 func dataUserRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
+	primaryEmail := d.Get("primary_email").(string)
+
 	var user *directory.User
 	var err error
 	err = retry(func() error {
-		user, err = config.directory.Users.Get(d.Get("primary_email").(string)).Do()
+		user, err = getUser(d, config, primaryEmail).Do()
 		return err
 	}, config.TimeoutMinutes)
 
 	if err != nil {
-		return handleNotFoundError(err, d, fmt.Sprintf("User %q", d.Id()))
+		if isNotFound(err) {
+			return fmt.Errorf("[ERROR] User %q does not exist", primaryEmail)
+		}
+		return fmt.Errorf("[ERROR] Error fetching user %q: %s", primaryEmail, err)
 	}
 
 	d.SetId(user.Id)
@@ -339,6 +365,7 @@ func dataUserRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("hash_function", user.HashFunction)
 	d.Set("suspension_reason", user.SuspensionReason)
 	d.Set("change_password_next_login", user.ChangePasswordAtNextLogin)
+	d.Set("include_in_global_address_list", user.IncludeInGlobalAddressList)
 	d.Set("include_in_global_list", user.IncludeInGlobalAddressList)
 	d.Set("is_ip_whitelisted", user.IpWhitelisted)
 	d.Set("is_admin", user.IsAdmin)
@@ -346,6 +373,9 @@ func dataUserRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("is_suspended", user.Suspended)
 	d.Set("2s_enrolled", user.IsEnrolledIn2Sv)
 	d.Set("2s_enforced", user.IsEnforcedIn2Sv)
+	d.Set("ip_whitelisted", user.IpWhitelisted)
+	d.Set("is_enforced_in_2sv", user.IsEnforcedIn2Sv)
+	d.Set("is_enrolled_in_2sv", user.IsEnrolledIn2Sv)
 	d.Set("aliases", user.Aliases)
 	d.Set("agreed_to_terms", user.AgreedToTerms)
 	d.Set("creation_time", user.CreationTime)
@@ -355,12 +385,24 @@ func dataUserRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("is_mailbox_setup", user.IsMailboxSetup)
 	d.Set("recovery_email", user.RecoveryEmail)
 	d.Set("recovery_phone", user.RecoveryPhone)
-	d.Set("name", flattenUserName(user.Name))
+	if user.Name != nil {
+		name := flattenUserName(user.Name)
+		name["full_name"] = user.Name.FullName
+		d.Set("name", name)
+	}
 	d.Set("posix_accounts", user.PosixAccounts)
 	d.Set("ssh_public_keys", user.SshPublicKeys)
-	d.Set("custom_schema", user.CustomSchemas)
 	d.Set("external_ids", user.ExternalIds)
 	d.Set("organizations", user.Organizations)
 
+	err, flattenedCustomSchema := flattenCustomSchema(user.CustomSchemas)
+	if err != nil {
+		return err
+	}
+
+	if err = d.Set("custom_schema", flattenedCustomSchema); err != nil {
+		return fmt.Errorf("Error setting custom_schema in state: %s", err.Error())
+	}
+
 	return nil
 }
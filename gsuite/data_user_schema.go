@@ -0,0 +1,112 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataUserSchema() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUserSchemaRead,
+		Schema: map[string]*schema.Schema{
+			"schema_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"schema_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"field": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"field_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"field_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"multi_valued": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"indexed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"read_access_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataUserSchemaRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	schemaName := d.Get("schema_name").(string)
+
+	var userSchema *directory.Schema
+	var err error
+	err = retry(func() error {
+		userSchema, err = config.directory.Schemas.Get(config.CustomerId, schemaName).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("[ERROR] User schema %q does not exist", schemaName)
+		}
+		return fmt.Errorf("[ERROR] Error fetching user schema %q: %s", schemaName, err)
+	}
+
+	fields := make([]map[string]interface{}, 0, len(userSchema.Fields))
+	for _, field := range userSchema.Fields {
+		fields = append(fields, map[string]interface{}{
+			"field_id":         field.FieldId,
+			"field_name":       field.FieldName,
+			"display_name":     field.DisplayName,
+			"field_type":       field.FieldType,
+			"multi_valued":     field.MultiValued,
+			"indexed":          field.Indexed == nil || *field.Indexed,
+			"read_access_type": field.ReadAccessType,
+		})
+	}
+
+	d.SetId(userSchema.SchemaId)
+	d.Set("schema_id", userSchema.SchemaId)
+	d.Set("schema_name", userSchema.SchemaName)
+	d.Set("display_name", userSchema.DisplayName)
+	d.Set("etag", userSchema.Etag)
+	if err := d.Set("field", fields); err != nil {
+		return fmt.Errorf("Error setting field in state: %s", err.Error())
+	}
+
+	return nil
+}
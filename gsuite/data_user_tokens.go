@@ -0,0 +1,87 @@
+package gsuite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataUserTokens() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUserTokensRead,
+		Schema: map[string]*schema.Schema{
+			// The user, by email, alias or id
+			"user_key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"tokens": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_text": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scopes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"anonymous": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"native_app": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataUserTokensRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userKey := strings.ToLower(d.Get("user_key").(string))
+
+	var tokens *directory.Tokens
+	var err error
+	err = retry(func() error {
+		tokens, err = config.directory.Tokens.List(userKey).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching tokens of user %s: %s", userKey, err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(tokens.Items))
+	for _, token := range tokens.Items {
+		result = append(result, map[string]interface{}{
+			"client_id":    token.ClientId,
+			"display_text": token.DisplayText,
+			"scopes":       token.Scopes,
+			"anonymous":    token.Anonymous,
+			"native_app":   token.NativeApp,
+		})
+	}
+
+	d.SetId(userKey)
+	if err := d.Set("tokens", result); err != nil {
+		return fmt.Errorf("Error setting tokens in state: %s", err.Error())
+	}
+
+	return nil
+}
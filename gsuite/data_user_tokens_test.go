@@ -0,0 +1,117 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// testTokensServer serves the tokens and application-specific passwords of
+// jane@domain.ext, revoking tokens and passwords deletes them.
+func testTokensServer(t *testing.T) (*Config, map[string]bool, map[string]bool) {
+	tokens := map[string]bool{"1234.apps.googleusercontent.com": true}
+	asps := map[string]bool{"42": true}
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		parts := strings.Split(r.URL.Path, "/")
+		last := parts[len(parts)-1]
+		if !strings.Contains(r.URL.Path, "/users/jane@domain.ext/") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/tokens"):
+			items := []string{}
+			for clientID := range tokens {
+				items = append(items, fmt.Sprintf(`{"clientId":%q,"displayText":"Some App","scopes":["openid","email"],"anonymous":false,"nativeApp":true}`, clientID))
+			}
+			fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/asps"):
+			items := []string{}
+			for codeID := range asps {
+				items = append(items, fmt.Sprintf(`{"codeId":%s,"name":"Mail client","creationTime":"1600000000000","lastTimeUsed":"1600000360000"}`, codeID))
+			}
+			fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+		case strings.Contains(r.URL.Path, "/tokens/"):
+			if !tokens[last] {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"code":404,"message":"Resource Not Found: clientId"}}`)
+				return
+			}
+			if r.Method == http.MethodDelete {
+				delete(tokens, last)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			fmt.Fprintf(w, `{"clientId":%q}`, last)
+		case strings.Contains(r.URL.Path, "/asps/"):
+			if !asps[last] {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"code":404,"message":"Resource Not Found: codeId"}}`)
+				return
+			}
+			if r.Method == http.MethodDelete {
+				delete(asps, last)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			fmt.Fprintf(w, `{"codeId":%s}`, last)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	return config, tokens, asps
+}
+
+func TestDataUserTokensRead(t *testing.T) {
+	config, _, _ := testTokensServer(t)
+
+	d := schema.TestResourceDataRaw(t, dataUserTokens().Schema, map[string]interface{}{
+		"user_key": "Jane@Domain.ext",
+	})
+	if err := dataUserTokensRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Id() != "jane@domain.ext" {
+		t.Fatalf("expected id jane@domain.ext, got %q", d.Id())
+	}
+	if got := d.Get("tokens.#").(int); got != 1 {
+		t.Fatalf("expected 1 token, got %d", got)
+	}
+	if got := d.Get("tokens.0.client_id").(string); got != "1234.apps.googleusercontent.com" {
+		t.Fatalf("expected client id 1234.apps.googleusercontent.com, got %q", got)
+	}
+	if got := d.Get("tokens.0.scopes").([]interface{}); len(got) != 2 || got[1] != "email" {
+		t.Fatalf("expected the scopes openid and email, got %v", got)
+	}
+	if !d.Get("tokens.0.native_app").(bool) || d.Get("tokens.0.anonymous").(bool) {
+		t.Fatalf("expected a native, non-anonymous app, got %v", d.Get("tokens"))
+	}
+}
+
+func TestDataUserAspsRead(t *testing.T) {
+	config, _, _ := testTokensServer(t)
+
+	d := schema.TestResourceDataRaw(t, dataUserAsps().Schema, map[string]interface{}{
+		"user_key": "jane@domain.ext",
+	})
+	if err := dataUserAspsRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := d.Get("asps.#").(int); got != 1 {
+		t.Fatalf("expected 1 application-specific password, got %d", got)
+	}
+	if got := d.Get("asps.0.code_id").(int); got != 42 {
+		t.Fatalf("expected code id 42, got %d", got)
+	}
+	if got := d.Get("asps.0.last_time_used").(int); got != 1600000360000 {
+		t.Fatalf("expected last time used 1600000360000, got %d", got)
+	}
+}
@@ -0,0 +1,180 @@
+package gsuite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataUsers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUsersRead,
+		Schema: map[string]*schema.Schema{
+			// See https://developers.google.com/admin-sdk/directory/v1/guides/search-users
+			"query": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"org_unit_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+			},
+
+			"show_deleted": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"primary_email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"given_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"family_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"full_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"org_unit_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_admin": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_suspended": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"archived": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_enrolled_in_2sv": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_login_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deletion_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// usersQuery adds the organizational unit to a user search query, the search
+// also matches the users of all child organizational units.
+func usersQuery(query, orgUnitPath string) string {
+	if orgUnitPath == "" || orgUnitPath == "/" {
+		return query
+	}
+
+	orgUnitQuery := fmt.Sprintf("orgUnitPath='%s'", strings.ReplaceAll(orgUnitPath, "'", "\\'"))
+	if query == "" {
+		return orgUnitQuery
+	}
+	return query + " " + orgUnitQuery
+}
+
+// listAPIUsersPages calls f with every page of users matching the query, so
+// that large directories can be processed a page at a time.
+func listAPIUsersPages(query string, showDeleted bool, config *Config, f func([]*directory.User) error) error {
+	return paginate(func(token string) (string, error) {
+		var response *directory.Users
+		var err error
+		err = retry(func() error {
+			call := config.directory.Users.List().Customer(config.CustomerId).MaxResults(500).PageToken(token)
+			if query != "" {
+				call = call.Query(query)
+			}
+			if showDeleted {
+				call = call.ShowDeleted(strconv.FormatBool(showDeleted))
+			}
+			response, err = call.Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return "", err
+		}
+		return response.NextPageToken, f(response.Users)
+	})
+}
+
+func flattenDataUser(user *directory.User) map[string]interface{} {
+	flattened := map[string]interface{}{
+		"id":                 user.Id,
+		"primary_email":      user.PrimaryEmail,
+		"org_unit_path":      user.OrgUnitPath,
+		"is_admin":           user.IsAdmin,
+		"is_suspended":       user.Suspended,
+		"archived":           user.Archived,
+		"is_enrolled_in_2sv": user.IsEnrolledIn2Sv,
+		"creation_time":      user.CreationTime,
+		"last_login_time":    user.LastLoginTime,
+		"deletion_time":      user.DeletionTime,
+	}
+	if user.Name != nil {
+		flattened["given_name"] = user.Name.GivenName
+		flattened["family_name"] = user.Name.FamilyName
+		flattened["full_name"] = user.Name.FullName
+	}
+	return flattened
+}
+
+func dataUsersRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	query := usersQuery(d.Get("query").(string), d.Get("org_unit_path").(string))
+	showDeleted := d.Get("show_deleted").(bool)
+
+	var result []map[string]interface{}
+	err := listAPIUsersPages(query, showDeleted, config, func(users []*directory.User) error {
+		for _, user := range users {
+			result = append(result, flattenDataUser(user))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching users matching %q: %s", query, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%t", config.CustomerId, query, showDeleted))
+	if err := d.Set("users", result); err != nil {
+		return fmt.Errorf("Error setting users in state: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataUsersRead_paginated(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("customer") != "C123" || q.Get("maxResults") != "500" || q.Get("showDeleted") != "true" {
+			t.Errorf("unexpected request parameters: %s", r.URL.RawQuery)
+		}
+		if q.Get("query") != "isSuspended=false orgUnitPath='/Engineering'" {
+			t.Errorf("unexpected query: %q", q.Get("query"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch q.Get("pageToken") {
+		case "":
+			fmt.Fprint(w, `{"users":[{"id":"1","primaryEmail":"one@domain.ext","orgUnitPath":"/Engineering","name":{"givenName":"One"}}],"nextPageToken":"page-2"}`)
+		case "page-2":
+			fmt.Fprint(w, `{"users":[{"id":"2","primaryEmail":"two@domain.ext","orgUnitPath":"/Engineering/Tools","isEnrolledIn2Sv":true}]}`)
+		default:
+			t.Errorf("unexpected page token: %q", q.Get("pageToken"))
+		}
+	})
+	config.CustomerId = "C123"
+
+	d := schema.TestResourceDataRaw(t, dataUsers().Schema, map[string]interface{}{
+		"query":         "isSuspended=false",
+		"org_unit_path": "/Engineering",
+		"show_deleted":  true,
+	})
+	if err := dataUsersRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if n := d.Get("users.#").(int); n != 2 {
+		t.Fatalf("expected the users of both pages, got %d users", n)
+	}
+	for i, expected := range []struct {
+		email       string
+		given       string
+		orgUnitPath string
+		enrolled    bool
+	}{
+		{"one@domain.ext", "One", "/Engineering", false},
+		{"two@domain.ext", "", "/Engineering/Tools", true},
+	} {
+		prefix := fmt.Sprintf("users.%d.", i)
+		if v := d.Get(prefix + "primary_email").(string); v != expected.email {
+			t.Errorf("expected %sprimary_email to be %q, got %q", prefix, expected.email, v)
+		}
+		if v := d.Get(prefix + "given_name").(string); v != expected.given {
+			t.Errorf("expected %sgiven_name to be %q, got %q", prefix, expected.given, v)
+		}
+		if v := d.Get(prefix + "org_unit_path").(string); v != expected.orgUnitPath {
+			t.Errorf("expected %sorg_unit_path to be %q, got %q", prefix, expected.orgUnitPath, v)
+		}
+		if v := d.Get(prefix + "is_enrolled_in_2sv").(bool); v != expected.enrolled {
+			t.Errorf("expected %sis_enrolled_in_2sv to be %t, got %t", prefix, expected.enrolled, v)
+		}
+	}
+}
+
+func TestUsersQuery(t *testing.T) {
+	testCases := []struct {
+		query       string
+		orgUnitPath string
+		expected    string
+	}{
+		{"", "/", ""},
+		{"isAdmin=true", "", "isAdmin=true"},
+		{"", "/Sales", "orgUnitPath='/Sales'"},
+		{"isAdmin=true", "/Sales Team", "isAdmin=true orgUnitPath='/Sales Team'"},
+		{"", "/O'Brien", `orgUnitPath='/O\'Brien'`},
+	}
+
+	for _, testCase := range testCases {
+		if actual := usersQuery(testCase.query, testCase.orgUnitPath); actual != testCase.expected {
+			t.Errorf("expected %q and %q to give %q, got %q", testCase.query, testCase.orgUnitPath, testCase.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataUsersWithout2SV() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUsersWithout2SVRead,
+		Schema: map[string]*schema.Schema{
+			"org_unit_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+			},
+
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"primary_email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"org_unit_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_enforced_in_2sv": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"emails": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataUsersWithout2SVRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	orgUnitPath := d.Get("org_unit_path").(string)
+
+	result := []map[string]interface{}{}
+	emails := []string{}
+	err := listAPIUsersPages(usersQuery("isEnrolledIn2Sv=false", orgUnitPath), false, config, func(users []*directory.User) error {
+		for _, user := range users {
+			// Don't rely on the search index alone
+			if user.IsEnrolledIn2Sv {
+				continue
+			}
+			result = append(result, map[string]interface{}{
+				"primary_email":      user.PrimaryEmail,
+				"org_unit_path":      user.OrgUnitPath,
+				"is_enforced_in_2sv": user.IsEnforcedIn2Sv,
+			})
+			emails = append(emails, user.PrimaryEmail)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching users without 2-step verification in %q: %s", orgUnitPath, err)
+	}
+
+	d.SetId(orgUnitPath)
+	if err := d.Set("users", result); err != nil {
+		return fmt.Errorf("Error setting users in state: %s", err.Error())
+	}
+	if err := d.Set("emails", emails); err != nil {
+		return fmt.Errorf("Error setting emails in state: %s", err.Error())
+	}
+
+	return nil
+}
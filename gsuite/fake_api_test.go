@@ -0,0 +1,48 @@
+package gsuite
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	directory "google.golang.org/api/admin/directory/v1"
+	reports "google.golang.org/api/admin/reports/v1"
+	gmail "google.golang.org/api/gmail/v1"
+	groupSettings "google.golang.org/api/groupssettings/v1"
+	"google.golang.org/api/option"
+)
+
+// testAPIConfig returns a config whose services send their calls to a fake
+// API served by handler. The fake API is shut down when the test ends.
+func testAPIConfig(t testing.TB, handler http.HandlerFunc) *Config {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	options := []option.ClientOption{option.WithHTTPClient(server.Client()), option.WithEndpoint(server.URL)}
+	directorySvc, err := directory.NewService(context.Background(), options...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	groupSettingsSvc, err := groupSettings.NewService(context.Background(), options...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	gmailSvc, err := gmail.NewService(context.Background(), options...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	reportsSvc, err := reports.NewService(context.Background(), options...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return &Config{
+		directory:      directorySvc,
+		groupSettings:  groupSettingsSvc,
+		gmail:          gmailSvc,
+		reports:        reportsSvc,
+		client:         server.Client(),
+		TimeoutMinutes: 1,
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/pkg/errors"
 )
 
@@ -23,12 +27,75 @@ func Provider() *schema.Provider {
 					"GCLOUD_KEYFILE_JSON",
 					"GOOGLE_APPLICATION_CREDENTIALS",
 				}, nil),
-				ValidateFunc: validateCredentials,
+				ValidateFunc:  validateCredentials,
+				ConflictsWith: []string{"service_account_key"},
+			},
+			"service_account_key": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"credentials"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_email": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateEmail,
+						},
+						"private_key": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+						"private_key_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"token_uri": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateHTTPSURL,
+						},
+					},
+				},
 			},
 			"impersonated_user_email": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"service_account": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GOOGLE_IMPERSONATE_SERVICE_ACCOUNT", nil),
+			},
+			"token_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateHTTPSURL,
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateProxyURL,
+			},
+			"ca_certificate": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"debug_api_calls": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"oauth_scopes": {
 				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
@@ -47,25 +114,112 @@ func Provider() *schema.Provider {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"skip_domain_check": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"read_projection": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "full",
+				ValidateFunc: validation.StringInSlice(userReadProjections, false),
+			},
+			"read_view_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "admin_view",
+				ValidateFunc: validation.StringInSlice(userReadViewTypes, false),
+			},
+			"retry_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_retries": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      5,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"initial_backoff_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"max_backoff_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      32,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"gsuite_group":           dataGroup(),
-			"gsuite_group_settings":  dataGroupSettings(),
-			"gsuite_user":            dataUser(),
-			"gsuite_user_attributes": dataUserAttributes(),
+			"gsuite_auth_check":         dataAuthCheck(),
+			"gsuite_buildings":          dataBuildings(),
+			"gsuite_calendar_resource":  dataCalendarResource(),
+			"gsuite_calendar_resources": dataCalendarResources(),
+			"gsuite_customer":           dataCustomer(),
+			"gsuite_group":              dataGroup(),
+			"gsuite_group_members":      dataGroupMembers(),
+			"gsuite_group_settings":     dataGroupSettings(),
+			"gsuite_groups":             dataGroups(),
+			"gsuite_login_activities":   dataLoginActivities(),
+			"gsuite_mobile_devices":     dataMobileDevices(),
+			"gsuite_org_unit":           dataOrgUnit(),
+			"gsuite_org_units":          dataOrgUnits(),
+			"gsuite_privileges":         dataPrivileges(),
+			"gsuite_role_assignments":   dataRoleAssignments(),
+			"gsuite_roles":              dataRoles(),
+			"gsuite_user":               dataUser(),
+			"gsuite_user_asps":          dataUserAsps(),
+			"gsuite_user_attributes":    dataUserAttributes(),
+			"gsuite_user_schema":        dataUserSchema(),
+			"gsuite_user_tokens":        dataUserTokens(),
+			"gsuite_users":              dataUsers(),
+			"gsuite_users_without_2sv":  dataUsersWithout2SV(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"gsuite_domain":          resourceDomain(),
-			"gsuite_group":           resourceGroup(),
-			"gsuite_group_member":    resourceGroupMember(),
-			"gsuite_group_members":   resourceGroupMembers(),
-			"gsuite_group_settings":  resourceGroupSettings(),
-			"gsuite_user":            resourceUser(),
-			"gsuite_user_attributes": resourceUserAttributes(),
-			"gsuite_user_schema":     resourceUserSchema(),
+			"gsuite_building":              resourceBuilding(),
+			"gsuite_calendar_resource":     resourceCalendarResource(),
+			"gsuite_chrome_device":         resourceChromeOSDevice(),
+			"gsuite_customer":              resourceCustomer(),
+			"gsuite_domain":                resourceDomain(),
+			"gsuite_domain_alias":          resourceDomainAlias(),
+			"gsuite_feature":               resourceCalendarFeature(),
+			"gsuite_group":                 resourceGroup(),
+			"gsuite_group_alias":           resourceGroupAlias(),
+			"gsuite_group_member":          resourceGroupMember(),
+			"gsuite_group_members":         resourceGroupMembers(),
+			"gsuite_group_settings":        resourceGroupSettings(),
+			"gsuite_mobile_device_action":  resourceMobileDeviceAction(),
+			"gsuite_org_unit":              resourceOrgUnit(),
+			"gsuite_role":                  resourceRole(),
+			"gsuite_role_assignment":       resourceRoleAssignment(),
+			"gsuite_user":                  resourceUser(),
+			"gsuite_user_alias":            resourceUserAlias(),
+			"gsuite_user_asp_revocation":   resourceUserAspRevocation(),
+			"gsuite_user_attributes":       resourceUserAttributes(),
+			"gsuite_user_gmail_sendas":     resourceUserGmailSendAs(),
+			"gsuite_user_make_admin":       resourceUserMakeAdmin(),
+			"gsuite_user_schema":           resourceUserSchema(),
+			"gsuite_user_thumbnail_photo":  resourceUserThumbnailPhoto(),
+			"gsuite_user_token_revocation": resourceUserTokenRevocation(),
 		},
 	}
 
+	for name, r := range p.DataSourcesMap {
+		withScopeCheck(name, r, dataSourceScopes[name])
+	}
+	for name, r := range p.ResourcesMap {
+		withScopeCheck(name, r, resourceScopes[name])
+	}
+
 	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
 		terraformVersion := p.TerraformVersion
 		if terraformVersion == "" {
@@ -79,13 +233,39 @@ func Provider() *schema.Provider {
 	return p
 }
 
+// oauthScopesEnvVar holds a comma separated list of scopes, which are added
+// to the default scopes when oauth_scopes is not configured.
+const oauthScopesEnvVar = "GSUITE_OAUTH_SCOPES"
+
 func oauthScopesFromConfigOrDefault(oauthScopesSet *schema.Set) []string {
 	oauthScopes := convertStringSet(oauthScopesSet)
-	if len(oauthScopes) == 0 {
-		log.Printf("[INFO] No Oauth Scopes provided. Using default oauth scopes.")
-		oauthScopes = defaultOauthScopes
+	if len(oauthScopes) > 0 {
+		return oauthScopes
+	}
+
+	if v := os.Getenv(oauthScopesEnvVar); v != "" {
+		log.Printf("[INFO] Using default oauth scopes and the oauth scopes from %s.", oauthScopesEnvVar)
+		return mergeOauthScopes(defaultOauthScopes, strings.Split(v, ","))
 	}
-	return oauthScopes
+
+	log.Printf("[INFO] No Oauth Scopes provided. Using default oauth scopes.")
+	return defaultOauthScopes
+}
+
+// mergeOauthScopes appends the extra scopes to the base scopes, skipping
+// empty entries and duplicates.
+func mergeOauthScopes(base, extra []string) []string {
+	seen := make(map[string]bool)
+	scopes := make([]string, 0, len(base)+len(extra))
+	for _, scope := range append(append([]string{}, base...), extra...) {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		scopes = append(scopes, scope)
+	}
+	return scopes
 }
 
 func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
@@ -122,13 +302,43 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 		updateExisting = v.(bool)
 	}
 
+	var retryConfig *RetryConfig
+	if v, ok := d.GetOk("retry_config"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		retryConfig = retryConfigFromMap(v.([]interface{})[0].(map[string]interface{}))
+	}
+
+	tokenURL := d.Get("token_url").(string)
+
+	var serviceAccountKey *accountFile
+	if v, ok := d.GetOk("service_account_key"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		m := v.([]interface{})[0].(map[string]interface{})
+		serviceAccountKey = serviceAccountKeyFromMap(m)
+
+		// The key block takes precedence over credentials from the environment
+		credentials = ""
+		if tokenURL == "" {
+			tokenURL = m["token_uri"].(string)
+		}
+	}
+
 	config := Config{
 		Credentials:           credentials,
+		ServiceAccountKey:     serviceAccountKey,
 		ImpersonatedUserEmail: impersonatedUserEmail,
 		OauthScopes:           oauthScopes,
 		CustomerId:            customerID,
 		TimeoutMinutes:        timeoutMinutes,
 		UpdateExisting:        updateExisting,
+		SkipDomainCheck:       d.Get("skip_domain_check").(bool),
+		ReadProjection:        d.Get("read_projection").(string),
+		ReadViewType:          d.Get("read_view_type").(string),
+		ServiceAccount:        d.Get("service_account").(string),
+		RetryConfig:           retryConfig,
+		TokenURL:              tokenURL,
+		ProxyURL:              d.Get("proxy_url").(string),
+		CACertificate:         d.Get("ca_certificate").(string),
+		DebugAPICalls:         d.Get("debug_api_calls").(bool),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
 	}
 
 	if err := config.loadAndValidate(terraformVersion); err != nil {
@@ -138,6 +348,25 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 	return &config, nil
 }
 
+func retryConfigFromMap(m map[string]interface{}) *RetryConfig {
+	return &RetryConfig{
+		MaxRetries:     m["max_retries"].(int),
+		InitialBackoff: time.Duration(m["initial_backoff_seconds"].(int)) * time.Second,
+		MaxBackoff:     time.Duration(m["max_backoff_seconds"].(int)) * time.Second,
+	}
+}
+
+// serviceAccountKeyFromMap builds the key of the service_account_key block,
+// the same fields a JSON key file holds.
+func serviceAccountKeyFromMap(m map[string]interface{}) *accountFile {
+	return &accountFile{
+		PrivateKeyId: m["private_key_id"].(string),
+		PrivateKey:   m["private_key"].(string),
+		ClientEmail:  m["client_email"].(string),
+		ClientId:     m["client_id"].(string),
+	}
+}
+
 func validateCredentials(v interface{}, k string) (warnings []string, errors []error) {
 	if v == nil || v.(string) == "" {
 		return
@@ -147,11 +376,46 @@ func validateCredentials(v interface{}, k string) (warnings []string, errors []e
 	if _, err := os.Stat(creds); err == nil {
 		return
 	}
+	contents, err := credentialsJSON(creds)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
 	var account accountFile
-	if err := json.Unmarshal([]byte(creds), &account); err != nil {
+	if err := json.Unmarshal([]byte(contents), &account); err != nil {
 		errors = append(errors,
 			fmt.Errorf("credentials are not valid JSON '%s': %s", creds, err))
 	}
 
 	return
 }
+
+func validateProxyURL(v interface{}, k string) (warnings []string, errors []error) {
+	if v == nil || v.(string) == "" {
+		return
+	}
+
+	if _, err := parseProxyURL(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %s", k, err))
+	}
+
+	return
+}
+
+func validateHTTPSURL(v interface{}, k string) (warnings []string, errors []error) {
+	if v == nil || v.(string) == "" {
+		return
+	}
+	value := v.(string)
+
+	u, err := url.Parse(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%s is not a valid URL '%s': %s", k, value, err))
+		return
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		errors = append(errors, fmt.Errorf("%s must be an https URL, got '%s'", k, value))
+	}
+
+	return
+}
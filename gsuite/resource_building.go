@@ -0,0 +1,236 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceBuilding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBuildingCreate,
+		Read:   resourceBuildingRead,
+		Update: resourceBuildingUpdate,
+		Delete: resourceBuildingDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"building_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"building_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// The order of the floors is meaningful to the API, it lists them
+			// from the lowest to the highest floor.
+			"floor_names": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"coordinates": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"latitude": {
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+						"longitude": {
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"etags": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandBuildingCoordinates(d *schema.ResourceData) *directory.BuildingCoordinates {
+	coordinates := d.Get("coordinates").([]interface{})
+	if len(coordinates) == 0 || coordinates[0] == nil {
+		return nil
+	}
+
+	c := coordinates[0].(map[string]interface{})
+	return &directory.BuildingCoordinates{
+		Latitude:        c["latitude"].(float64),
+		Longitude:       c["longitude"].(float64),
+		ForceSendFields: []string{"Latitude", "Longitude"},
+	}
+}
+
+func flattenBuildingCoordinates(coordinates *directory.BuildingCoordinates) []map[string]interface{} {
+	if coordinates == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"latitude":  coordinates.Latitude,
+			"longitude": coordinates.Longitude,
+		},
+	}
+}
+
+func resourceBuildingCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	building := &directory.Building{
+		BuildingId:   d.Get("building_id").(string),
+		BuildingName: d.Get("building_name").(string),
+		Description:  d.Get("description").(string),
+		FloorNames:   convertStringArray(d.Get("floor_names").([]interface{})),
+		Coordinates:  expandBuildingCoordinates(d),
+	}
+
+	var createdBuilding *directory.Building
+	err = retry(func() error {
+		createdBuilding, err = config.directory.Resources.Buildings.Insert(customerID, building).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating building: %s", err)
+	}
+
+	d.SetId(createdBuilding.BuildingId)
+	log.Printf("[INFO] Created building: %s", createdBuilding.BuildingName)
+	return resourceBuildingRead(d, meta)
+}
+
+func resourceBuildingRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	var building *directory.Building
+	err = retry(func() error {
+		building, err = config.directory.Resources.Buildings.Get(customerID, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Building %q", d.Id()))
+	}
+
+	d.SetId(building.BuildingId)
+	d.Set("building_id", building.BuildingId)
+	d.Set("building_name", building.BuildingName)
+	d.Set("description", building.Description)
+	d.Set("floor_names", building.FloorNames)
+	d.Set("coordinates", flattenBuildingCoordinates(building.Coordinates))
+	d.Set("etags", building.Etags)
+
+	return nil
+}
+
+func resourceBuildingUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	building := &directory.Building{}
+	nullFields := []string{}
+
+	if d.HasChange("building_name") {
+		log.Printf("[DEBUG] Updating building name: %s", d.Get("building_name").(string))
+		building.BuildingName = d.Get("building_name").(string)
+	}
+
+	if d.HasChange("description") {
+		log.Printf("[DEBUG] Updating building description: %s", d.Get("description").(string))
+		building.Description = d.Get("description").(string)
+		if building.Description == "" {
+			nullFields = append(nullFields, "Description")
+		}
+	}
+
+	if d.HasChange("floor_names") {
+		log.Printf("[DEBUG] Updating building floor_names")
+		building.FloorNames = convertStringArray(d.Get("floor_names").([]interface{}))
+	}
+
+	if d.HasChange("coordinates") {
+		log.Printf("[DEBUG] Updating building coordinates")
+		building.Coordinates = expandBuildingCoordinates(d)
+		if building.Coordinates == nil {
+			nullFields = append(nullFields, "Coordinates")
+		}
+	}
+
+	if len(nullFields) > 0 {
+		building.NullFields = nullFields
+	}
+
+	var updatedBuilding *directory.Building
+	err = retry(func() error {
+		updatedBuilding, err = config.directory.Resources.Buildings.Patch(customerID, d.Id(), building).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating building: %s", err)
+	}
+
+	log.Printf("[INFO] Updated building: %s", updatedBuilding.BuildingName)
+	return resourceBuildingRead(d, meta)
+}
+
+func resourceBuildingDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		err = config.directory.Resources.Buildings.Delete(customerID, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting building: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
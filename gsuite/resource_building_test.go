@@ -0,0 +1,96 @@
+package gsuite
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceBuilding_calendarResource(t *testing.T) {
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBuildingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBuildingConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBuildingExists("gsuite_building.test"),
+					resource.TestCheckResourceAttr("gsuite_building.test", "floor_names.#", "3"),
+					resource.TestCheckResourceAttr("gsuite_building.test", "floor_names.0", "B1"),
+					resource.TestCheckResourceAttr("gsuite_building.test", "floor_names.2", "2"),
+					resource.TestCheckResourceAttr("gsuite_calendar_resource.test", "building_id", name),
+					resource.TestCheckResourceAttr("gsuite_calendar_resource.test", "floor_name", "2"),
+					resource.TestCheckResourceAttrSet("gsuite_calendar_resource.test", "resource_email"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBuildingExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		_, err := config.directory.Resources.Buildings.Get(config.CustomerId, rs.Primary.ID).Do()
+		return err
+	}
+}
+
+func testAccCheckBuildingDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		var err error
+		switch rs.Type {
+		case "gsuite_building":
+			_, err = config.directory.Resources.Buildings.Get(config.CustomerId, rs.Primary.ID).Do()
+		case "gsuite_calendar_resource":
+			_, err = config.directory.Resources.Calendars.Get(config.CustomerId, rs.Primary.ID).Do()
+		default:
+			continue
+		}
+
+		if err == nil {
+			return fmt.Errorf("%s %s still exists", rs.Type, rs.Primary.ID)
+		}
+		if !strings.Contains(err.Error(), "404") {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccResourceBuildingConfig(name string) string {
+	return fmt.Sprintf(`
+resource "gsuite_building" "test" {
+  building_id   = "%[1]s"
+  building_name = "%[1]s"
+  floor_names   = ["B1", "1", "2"]
+
+  coordinates {
+    latitude  = 52.3676
+    longitude = 4.9041
+  }
+}
+
+resource "gsuite_calendar_resource" "test" {
+  resource_id   = "%[1]s"
+  resource_name = "%[1]s room"
+  capacity      = 4
+  building_id   = gsuite_building.test.building_id
+  floor_name    = "2"
+}
+`, name)
+}
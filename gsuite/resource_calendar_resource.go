@@ -0,0 +1,235 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceCalendarResource() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCalendarResourceCreate,
+		Read:   resourceCalendarResourceRead,
+		Update: resourceCalendarResourceUpdate,
+		Delete: resourceCalendarResourceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"resource_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"resource_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"resource_category": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"CONFERENCE_ROOM", "OTHER"}, false),
+			},
+
+			"resource_description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"user_visible_description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"capacity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"building_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"floor_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"floor_section": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"resource_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"generated_resource_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etags": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCalendarResourceCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	calendarResource := &directory.CalendarResource{
+		ResourceId:             d.Get("resource_id").(string),
+		ResourceName:           d.Get("resource_name").(string),
+		ResourceType:           d.Get("resource_type").(string),
+		ResourceCategory:       d.Get("resource_category").(string),
+		ResourceDescription:    d.Get("resource_description").(string),
+		UserVisibleDescription: d.Get("user_visible_description").(string),
+		Capacity:               int64(d.Get("capacity").(int)),
+		BuildingId:             d.Get("building_id").(string),
+		FloorName:              d.Get("floor_name").(string),
+		FloorSection:           d.Get("floor_section").(string),
+	}
+
+	var createdCalendarResource *directory.CalendarResource
+	var err error
+	err = retry(func() error {
+		createdCalendarResource, err = config.directory.Resources.Calendars.Insert(config.CustomerId, calendarResource).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating calendar resource: %s", err)
+	}
+
+	d.SetId(createdCalendarResource.ResourceId)
+	log.Printf("[INFO] Created calendar resource: %s", createdCalendarResource.ResourceName)
+	return resourceCalendarResourceRead(d, meta)
+}
+
+func resourceCalendarResourceRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var calendarResource *directory.CalendarResource
+	var err error
+	err = retry(func() error {
+		calendarResource, err = config.directory.Resources.Calendars.Get(config.CustomerId, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Calendar resource %q", d.Id()))
+	}
+
+	d.SetId(calendarResource.ResourceId)
+	d.Set("resource_id", calendarResource.ResourceId)
+	d.Set("resource_name", calendarResource.ResourceName)
+	d.Set("resource_type", calendarResource.ResourceType)
+	d.Set("resource_category", calendarResource.ResourceCategory)
+	d.Set("resource_description", calendarResource.ResourceDescription)
+	d.Set("user_visible_description", calendarResource.UserVisibleDescription)
+	d.Set("capacity", calendarResource.Capacity)
+	d.Set("building_id", calendarResource.BuildingId)
+	d.Set("floor_name", calendarResource.FloorName)
+	d.Set("floor_section", calendarResource.FloorSection)
+	d.Set("resource_email", calendarResource.ResourceEmail)
+	d.Set("generated_resource_name", calendarResource.GeneratedResourceName)
+	d.Set("etags", calendarResource.Etags)
+
+	return nil
+}
+
+func resourceCalendarResourceUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	calendarResource := &directory.CalendarResource{}
+	nullFields := []string{}
+	forceSendFields := []string{}
+
+	// Optional string fields which are removed when they're emptied
+	stringFields := map[string]struct {
+		field  string
+		target *string
+	}{
+		"resource_name":            {"ResourceName", &calendarResource.ResourceName},
+		"resource_type":            {"ResourceType", &calendarResource.ResourceType},
+		"resource_category":        {"ResourceCategory", &calendarResource.ResourceCategory},
+		"resource_description":     {"ResourceDescription", &calendarResource.ResourceDescription},
+		"user_visible_description": {"UserVisibleDescription", &calendarResource.UserVisibleDescription},
+		"building_id":              {"BuildingId", &calendarResource.BuildingId},
+		"floor_name":               {"FloorName", &calendarResource.FloorName},
+		"floor_section":            {"FloorSection", &calendarResource.FloorSection},
+	}
+	for key, f := range stringFields {
+		if !d.HasChange(key) {
+			continue
+		}
+		value := d.Get(key).(string)
+		log.Printf("[DEBUG] Updating calendar resource %s: %s", key, value)
+		*f.target = value
+		if value == "" {
+			nullFields = append(nullFields, f.field)
+		}
+	}
+
+	if d.HasChange("capacity") {
+		log.Printf("[DEBUG] Updating calendar resource capacity: %d", d.Get("capacity").(int))
+		calendarResource.Capacity = int64(d.Get("capacity").(int))
+		forceSendFields = append(forceSendFields, "Capacity")
+	}
+
+	if len(nullFields) > 0 {
+		calendarResource.NullFields = nullFields
+	}
+	if len(forceSendFields) > 0 {
+		calendarResource.ForceSendFields = forceSendFields
+	}
+
+	var updatedCalendarResource *directory.CalendarResource
+	var err error
+	err = retry(func() error {
+		updatedCalendarResource, err = config.directory.Resources.Calendars.Patch(config.CustomerId, d.Id(), calendarResource).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating calendar resource: %s", err)
+	}
+
+	log.Printf("[INFO] Updated calendar resource: %s", updatedCalendarResource.ResourceName)
+	return resourceCalendarResourceRead(d, meta)
+}
+
+func resourceCalendarResourceDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var err error
+	err = retry(func() error {
+		err = config.directory.Resources.Calendars.Delete(config.CustomerId, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting calendar resource: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
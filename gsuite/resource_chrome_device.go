@@ -0,0 +1,279 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// Chrome OS devices are enrolled on the device itself, they can't be created
+// or deleted through the API. The resource attaches to an enrolled device and
+// manages its organizational unit and annotations.
+func resourceChromeOSDevice() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceChromeOSDeviceCreate,
+		Read:   resourceChromeOSDeviceRead,
+		Update: resourceChromeOSDeviceUpdate,
+		Delete: resourceChromeOSDeviceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"device_id", "serial_number"},
+			},
+
+			"serial_number": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"org_unit_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"annotated_user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"annotated_location": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"annotated_asset_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Applied whenever it changes, the resulting state of the device
+			// is reflected by status.
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"disable", "reenable"}, false),
+			},
+
+			// Deprovisioning can only be undone by enrolling the device again,
+			// so it only happens on destroy when a reason is given.
+			"deprovision_reason": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"different_model_replacement", "retiring_device", "same_model_replacement", "upgrade_transfer",
+				}, false),
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"model": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// findChromeOSDevice gets a device by its ID, or looks it up by its serial
+// number when no ID is given.
+func findChromeOSDevice(config *Config, deviceID, serialNumber string) (*directory.ChromeOsDevice, error) {
+	if deviceID != "" {
+		var device *directory.ChromeOsDevice
+		var err error
+		err = retry(func() error {
+			device, err = config.directory.Chromeosdevices.Get(config.CustomerId, deviceID).Projection("BASIC").Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error fetching Chrome OS device %s, make sure the device is enrolled: %s", deviceID, err)
+		}
+		return device, nil
+	}
+
+	var devices *directory.ChromeOsDevices
+	var err error
+	err = retry(func() error {
+		devices, err = config.directory.Chromeosdevices.List(config.CustomerId).Query(fmt.Sprintf("id:%s", serialNumber)).Projection("BASIC").Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error searching Chrome OS device %s: %s", serialNumber, err)
+	}
+
+	// The query matches serial numbers by prefix
+	for _, device := range devices.Chromeosdevices {
+		if strings.EqualFold(device.SerialNumber, serialNumber) {
+			return device, nil
+		}
+	}
+	return nil, fmt.Errorf("[ERROR] No Chrome OS device found with serial number %s, make sure the device is enrolled", serialNumber)
+}
+
+func chromeOSDeviceAction(config *Config, deviceID string, action *directory.ChromeOsDeviceAction) error {
+	log.Printf("[DEBUG] Applying Chrome OS device action %s to %s", action.Action, deviceID)
+
+	err := retry(func() error {
+		return config.directory.Chromeosdevices.Action(config.CustomerId, deviceID, action).Do()
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error applying action %s to Chrome OS device %s: %s", action.Action, deviceID, err)
+	}
+	return nil
+}
+
+func resourceChromeOSDeviceCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	device, err := findChromeOSDevice(config, d.Get("device_id").(string), d.Get("serial_number").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(device.DeviceId)
+	log.Printf("[INFO] Attached to Chrome OS device: %s (%s)", device.DeviceId, device.SerialNumber)
+
+	return resourceChromeOSDeviceUpdate(d, meta)
+}
+
+func resourceChromeOSDeviceRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var device *directory.ChromeOsDevice
+	var err error
+	err = retry(func() error {
+		device, err = config.directory.Chromeosdevices.Get(config.CustomerId, d.Id()).Projection("BASIC").Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Chrome OS device %q", d.Id()))
+	}
+
+	d.SetId(device.DeviceId)
+	d.Set("device_id", device.DeviceId)
+	d.Set("serial_number", device.SerialNumber)
+	d.Set("org_unit_path", device.OrgUnitPath)
+	d.Set("annotated_user", device.AnnotatedUser)
+	d.Set("annotated_location", device.AnnotatedLocation)
+	d.Set("annotated_asset_id", device.AnnotatedAssetId)
+	d.Set("notes", device.Notes)
+	d.Set("status", device.Status)
+	d.Set("model", device.Model)
+	d.Set("etag", device.Etag)
+
+	return nil
+}
+
+func resourceChromeOSDeviceUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	device := &directory.ChromeOsDevice{}
+	nullFields := []string{}
+	changed := false
+
+	// Optional string fields which are removed when they're emptied
+	stringFields := map[string]struct {
+		field  string
+		target *string
+	}{
+		"annotated_user":     {"AnnotatedUser", &device.AnnotatedUser},
+		"annotated_location": {"AnnotatedLocation", &device.AnnotatedLocation},
+		"annotated_asset_id": {"AnnotatedAssetId", &device.AnnotatedAssetId},
+		"notes":              {"Notes", &device.Notes},
+	}
+	for key, f := range stringFields {
+		if !d.HasChange(key) {
+			continue
+		}
+		value := d.Get(key).(string)
+		log.Printf("[DEBUG] Updating Chrome OS device %s: %s", key, value)
+		*f.target = value
+		if value == "" {
+			nullFields = append(nullFields, f.field)
+		}
+		changed = true
+	}
+
+	if v, ok := d.GetOk("org_unit_path"); ok && d.HasChange("org_unit_path") {
+		log.Printf("[DEBUG] Updating Chrome OS device org_unit_path: %s", v.(string))
+		device.OrgUnitPath = v.(string)
+		changed = true
+	}
+
+	if len(nullFields) > 0 {
+		device.NullFields = nullFields
+	}
+
+	if changed {
+		var err error
+		err = retry(func() error {
+			_, err = config.directory.Chromeosdevices.Patch(config.CustomerId, d.Id(), device).Do()
+			return err
+		}, config.TimeoutMinutes)
+
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error updating Chrome OS device: %s", err)
+		}
+		log.Printf("[INFO] Updated Chrome OS device: %s", d.Id())
+	}
+
+	if v, ok := d.GetOk("action"); ok && d.HasChange("action") {
+		err := chromeOSDeviceAction(config, d.Id(), &directory.ChromeOsDeviceAction{Action: v.(string)})
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceChromeOSDeviceRead(d, meta)
+}
+
+func resourceChromeOSDeviceDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	reason := d.Get("deprovision_reason").(string)
+	if reason == "" {
+		log.Printf("[WARN] Chrome OS device %s can't be deleted, removing it from state only. Set deprovision_reason to deprovision it", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	err := chromeOSDeviceAction(config, d.Id(), &directory.ChromeOsDeviceAction{
+		Action:            "deprovision",
+		DeprovisionReason: reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deprovisioned Chrome OS device: %s", d.Id())
+	d.SetId("")
+	return nil
+}
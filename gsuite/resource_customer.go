@@ -0,0 +1,248 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// customerPostalAddressFields are the fields of the postal address of a
+// customer.
+var customerPostalAddressFields = []string{
+	"contact_name",
+	"organization_name",
+	"address_line1",
+	"address_line2",
+	"address_line3",
+	"locality",
+	"region",
+	"postal_code",
+	"country_code",
+}
+
+// There is exactly one customer, which can't be created or deleted. Creating
+// the resource adopts the customer of the provider, destroying it only removes
+// it from the state.
+func resourceCustomer() *schema.Resource {
+	postalAddress := map[string]*schema.Schema{}
+	for _, field := range customerPostalAddressFields {
+		postalAddress[field] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+
+	return &schema.Resource{
+		Create: resourceCustomerCreate,
+		Read:   resourceCustomerRead,
+		Update: resourceCustomerUpdate,
+		Delete: resourceCustomerDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCustomerImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// The primary domain of the customer
+			"customer_domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// Fields which aren't configured are left as they are
+			"alternate_email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateEmail,
+			},
+
+			"phone_number": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validatePhoneE164,
+			},
+
+			"language": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateLanguageCode,
+			},
+
+			"postal_address": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: postalAddress,
+				},
+			},
+
+			"customer_creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func flattenCustomerPostalAddress(address *directory.CustomerPostalAddress) []map[string]interface{} {
+	if address == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{{
+		"contact_name":      address.ContactName,
+		"organization_name": address.OrganizationName,
+		"address_line1":     address.AddressLine1,
+		"address_line2":     address.AddressLine2,
+		"address_line3":     address.AddressLine3,
+		"locality":          address.Locality,
+		"region":            address.Region,
+		"postal_code":       address.PostalCode,
+		"country_code":      address.CountryCode,
+	}}
+}
+
+// expandCustomerPostalAddress returns the configured postal address, the
+// address is replaced as a whole so unset fields are cleared.
+func expandCustomerPostalAddress(list []interface{}) *directory.CustomerPostalAddress {
+	address := &directory.CustomerPostalAddress{
+		ForceSendFields: []string{"ContactName", "OrganizationName", "AddressLine1", "AddressLine2", "AddressLine3", "Locality", "Region", "PostalCode", "CountryCode"},
+	}
+	if len(list) == 0 || list[0] == nil {
+		return address
+	}
+
+	m := list[0].(map[string]interface{})
+	address.ContactName = m["contact_name"].(string)
+	address.OrganizationName = m["organization_name"].(string)
+	address.AddressLine1 = m["address_line1"].(string)
+	address.AddressLine2 = m["address_line2"].(string)
+	address.AddressLine3 = m["address_line3"].(string)
+	address.Locality = m["locality"].(string)
+	address.Region = m["region"].(string)
+	address.PostalCode = m["postal_code"].(string)
+	address.CountryCode = m["country_code"].(string)
+	return address
+}
+
+// customerPatch returns the changes to the customer, or all configured fields
+// when the customer is adopted.
+func customerPatch(d *schema.ResourceData, adopt bool) *directory.Customer {
+	customer := &directory.Customer{}
+	changed := func(key string) bool {
+		if adopt {
+			_, ok := d.GetOk(key)
+			return ok
+		}
+		return d.HasChange(key)
+	}
+
+	if changed("alternate_email") {
+		log.Printf("[DEBUG] Updating customer alternate_email: %s", d.Get("alternate_email").(string))
+		customer.AlternateEmail = d.Get("alternate_email").(string)
+	}
+	if changed("phone_number") {
+		log.Printf("[DEBUG] Updating customer phone_number: %s", d.Get("phone_number").(string))
+		customer.PhoneNumber = d.Get("phone_number").(string)
+	}
+	if changed("language") {
+		log.Printf("[DEBUG] Updating customer language: %s", d.Get("language").(string))
+		customer.Language = d.Get("language").(string)
+	}
+	if changed("postal_address") {
+		log.Printf("[DEBUG] Updating customer postal_address")
+		customer.PostalAddress = expandCustomerPostalAddress(d.Get("postal_address").([]interface{}))
+	}
+
+	return customer
+}
+
+func customerUpdate(d *schema.ResourceData, config *Config, customerKey string, adopt bool) error {
+	customer := customerPatch(d, adopt)
+
+	var updatedCustomer *directory.Customer
+	var err error
+	err = retry(func() error {
+		updatedCustomer, err = config.directory.Customers.Patch(customerKey, customer).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating customer %s: %s", customerKey, err)
+	}
+
+	d.SetId(updatedCustomer.Id)
+	log.Printf("[INFO] Updated customer: %s", updatedCustomer.Id)
+	return nil
+}
+
+func resourceCustomerCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if err := customerUpdate(d, config, config.CustomerId, true); err != nil {
+		return err
+	}
+
+	return resourceCustomerRead(d, meta)
+}
+
+func resourceCustomerUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if err := customerUpdate(d, config, d.Id(), false); err != nil {
+		return err
+	}
+
+	return resourceCustomerRead(d, meta)
+}
+
+func resourceCustomerRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var customer *directory.Customer
+	var err error
+	err = retry(func() error {
+		customer, err = config.directory.Customers.Get(d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Customer %q", d.Id()))
+	}
+
+	d.SetId(customer.Id)
+	d.Set("customer_domain", customer.CustomerDomain)
+	d.Set("alternate_email", customer.AlternateEmail)
+	d.Set("phone_number", customer.PhoneNumber)
+	d.Set("language", customer.Language)
+	d.Set("customer_creation_time", customer.CustomerCreationTime)
+	d.Set("etag", customer.Etag)
+	if err := d.Set("postal_address", flattenCustomerPostalAddress(customer.PostalAddress)); err != nil {
+		return fmt.Errorf("Error setting postal_address in state: %s", err.Error())
+	}
+
+	return nil
+}
+
+func resourceCustomerDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] Customer %s can't be deleted, removing it from state only", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using my_customer or the ID of the customer, the ID is
+// resolved on read
+func resourceCustomerImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,126 @@
+package gsuite
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// testCustomerServer keeps the customer C0123abcd of domain.ext, patches are
+// recorded and merged into it.
+func testCustomerServer(t *testing.T) (*Config, *[]map[string]interface{}) {
+	customer := map[string]interface{}{
+		"id":             "C0123abcd",
+		"customerDomain": "domain.ext",
+		"alternateEmail": "it@other.ext",
+		"language":       "en",
+		"postalAddress":  map[string]interface{}{"organizationName": "Domain", "countryCode": "DE"},
+	}
+	patches := []map[string]interface{}{}
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/customers/my_customer") && !strings.HasSuffix(r.URL.Path, "/customers/C0123abcd") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Method == http.MethodPatch {
+			body, _ := ioutil.ReadAll(r.Body)
+			patch := map[string]interface{}{}
+			if err := json.Unmarshal(body, &patch); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			patches = append(patches, patch)
+			for k, v := range patch {
+				customer[k] = v
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(customer)
+	})
+	config.CustomerId = "my_customer"
+
+	return config, &patches
+}
+
+func TestResourceCustomerCreate(t *testing.T) {
+	config, patches := testCustomerServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceCustomer().Schema, map[string]interface{}{
+		"phone_number": "+31201234567",
+	})
+	if err := resourceCustomerCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(*patches) != 1 || fmt.Sprint((*patches)[0]) != "map[phoneNumber:+31201234567]" {
+		t.Fatalf("expected only the phone number to be patched, got %v", *patches)
+	}
+	if d.Id() != "C0123abcd" || d.Get("alternate_email").(string) != "it@other.ext" || d.Get("postal_address.0.country_code").(string) != "DE" {
+		t.Fatalf("expected the existing customer to be adopted, got id %q and state %v", d.Id(), d.State())
+	}
+}
+
+func TestResourceCustomerUpdate_postalAddress(t *testing.T) {
+	config, patches := testCustomerServer(t)
+
+	r := resourceCustomer()
+	state := &terraform.InstanceState{
+		ID: "C0123abcd",
+		Attributes: map[string]string{
+			"id":                                 "C0123abcd",
+			"alternate_email":                    "it@other.ext",
+			"language":                           "en",
+			"postal_address.#":                   "1",
+			"postal_address.0.organization_name": "Domain",
+			"postal_address.0.country_code":      "DE",
+		},
+	}
+	config2 := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"language": "de-DE",
+		"postal_address": []interface{}{map[string]interface{}{
+			"organization_name": "Domain GmbH",
+			"locality":          "Düsseldorf",
+			"country_code":      "DE",
+		}},
+	})
+
+	diff, err := r.Diff(state, config2, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := r.Apply(state, diff, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(*patches) != 1 {
+		t.Fatalf("expected 1 patch, got %v", *patches)
+	}
+	patch := (*patches)[0]
+	if _, ok := patch["alternateEmail"]; ok || patch["language"] != "de-DE" {
+		t.Fatalf("expected only the changed fields to be patched, got %v", patch)
+	}
+	address := patch["postalAddress"].(map[string]interface{})
+	if address["organizationName"] != "Domain GmbH" || address["locality"] != "Düsseldorf" || address["contactName"] != "" {
+		t.Fatalf("expected the postal address to be replaced as a whole, got %v", address)
+	}
+}
+
+func TestResourceCustomerImporter(t *testing.T) {
+	config, _ := testCustomerServer(t)
+
+	d := resourceCustomer().Data(&terraform.InstanceState{ID: "my_customer"})
+	states, err := resourceCustomerImporter(d, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := resourceCustomerRead(states[0], config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if states[0].Id() != "C0123abcd" || states[0].Get("customer_domain").(string) != "domain.ext" {
+		t.Fatalf("expected my_customer to resolve to C0123abcd, got %q", states[0].Id())
+	}
+}
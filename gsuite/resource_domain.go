@@ -3,6 +3,7 @@ package gsuite
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -36,6 +37,16 @@ func resourceDomain() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"is_primary": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"verified": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -99,6 +110,10 @@ func resourceDomainRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(domain.DomainName)
 	d.Set("domain_name", domain.DomainName)
+	d.Set("creation_time", strconv.FormatInt(domain.CreationTime, 10))
+	d.Set("etag", domain.Etag)
+	d.Set("is_primary", domain.IsPrimary)
+	d.Set("verified", domain.Verified)
 
 	return nil
 }
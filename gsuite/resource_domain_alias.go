@@ -0,0 +1,149 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceDomainAlias() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDomainAliasCreate,
+		Read:   resourceDomainAliasRead,
+		Delete: resourceDomainAliasDelete,
+		// Aliases can not be renamed, there is no update method
+
+		Schema: map[string]*schema.Schema{
+			"domain_alias_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+
+			"parent_domain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+
+			"creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"verified": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDomainAliasCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	domainAlias := &directory.DomainAlias{
+		DomainAliasName:  strings.ToLower(d.Get("domain_alias_name").(string)),
+		ParentDomainName: strings.ToLower(d.Get("parent_domain_name").(string)),
+	}
+	log.Printf("[DEBUG] Setting %s: %s", "domain_alias_name", domainAlias.DomainAliasName)
+	log.Printf("[DEBUG] Setting %s: %s", "parent_domain_name", domainAlias.ParentDomainName)
+
+	var createdDomainAlias *directory.DomainAlias
+	var err error
+	err = retry(func() error {
+		createdDomainAlias, err = config.directory.DomainAliases.Insert(config.CustomerId, domainAlias).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if !parentDomainExists(config, domainAlias.ParentDomainName) {
+			return fmt.Errorf("[ERROR] Error creating domain alias %s: parent domain %q does not exist", domainAlias.DomainAliasName, domainAlias.ParentDomainName)
+		}
+		return fmt.Errorf("[ERROR] Error creating domain alias: %s", err)
+	}
+
+	// There is no id as such for a DomainAlias resource, therefore we use
+	// DomainAliasName as unique identifier.
+	d.SetId(createdDomainAlias.DomainAliasName)
+
+	log.Printf("[INFO] Created domain alias: %s", createdDomainAlias.DomainAliasName)
+	return resourceDomainAliasRead(d, meta)
+}
+
+func resourceDomainAliasRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var domainAlias *directory.DomainAlias
+	var err error
+	err = retry(func() error {
+		domainAlias, err = config.directory.DomainAliases.Get(config.CustomerId, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if isNotFound(err) {
+			parentDomainName := d.Get("parent_domain_name").(string)
+			if !parentDomainExists(config, parentDomainName) {
+				log.Printf("[WARN] Parent domain %q of domain alias %q no longer exists", parentDomainName, d.Id())
+			}
+		}
+		return handleNotFoundError(err, d, fmt.Sprintf("Domain alias %q", d.Id()))
+	}
+
+	d.SetId(domainAlias.DomainAliasName)
+	d.Set("domain_alias_name", domainAlias.DomainAliasName)
+	d.Set("parent_domain_name", domainAlias.ParentDomainName)
+	d.Set("creation_time", strconv.FormatInt(domainAlias.CreationTime, 10))
+	d.Set("etag", domainAlias.Etag)
+	d.Set("verified", domainAlias.Verified)
+
+	return nil
+}
+
+func resourceDomainAliasDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var err error
+	err = retry(func() error {
+		err = config.directory.DomainAliases.Delete(config.CustomerId, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Domain alias %q is already gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error deleting domain alias: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// parentDomainExists is used to explain a failing domain alias call, any
+// error other than a 404 is considered as the domain existing.
+func parentDomainExists(config *Config, domainName string) bool {
+	_, err := config.directory.Domains.Get(config.CustomerId, domainName).Do()
+	if isNotFound(err) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package gsuite
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// The domain needs to be owned by the customer for the verification checks to
+// pass, so it is supplied through the environment.
+const testAccDomainEnvVar = "GSUITE_TEST_DOMAIN"
+
+func TestAccResourceDomain_basic(t *testing.T) {
+	domainName := os.Getenv(testAccDomainEnvVar)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if domainName == "" {
+				t.Skipf("%s must be set for domain acceptance tests", testAccDomainEnvVar)
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDomainConfig(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gsuite_domain.test", "domain_name", domainName),
+					resource.TestCheckResourceAttr("gsuite_domain.test", "is_primary", "false"),
+					resource.TestCheckResourceAttrSet("gsuite_domain.test", "creation_time"),
+					resource.TestCheckResourceAttrSet("gsuite_domain.test", "verified"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDomainDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gsuite_domain" {
+			continue
+		}
+
+		_, err := config.directory.Domains.Get(config.CustomerId, rs.Primary.ID).Do()
+		if err == nil {
+			return fmt.Errorf("Domain %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccResourceDomainConfig(domainName string) string {
+	return fmt.Sprintf(`
+resource "gsuite_domain" "test" {
+  domain_name = "%s"
+}
+`, domainName)
+}
@@ -0,0 +1,153 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceCalendarFeature() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCalendarFeatureCreate,
+		Read:   resourceCalendarFeatureRead,
+		Update: resourceCalendarFeatureUpdate,
+		Delete: resourceCalendarFeatureDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// The name is the key of the feature, changing it renames the
+			// feature in place so that the rooms referencing it keep it.
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"etags": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCalendarFeatureCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	feature := &directory.Feature{
+		Name: d.Get("name").(string),
+	}
+
+	var createdFeature *directory.Feature
+	err = retry(func() error {
+		createdFeature, err = config.directory.Resources.Features.Insert(customerID, feature).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating feature: %s", err)
+	}
+
+	d.SetId(createdFeature.Name)
+	log.Printf("[INFO] Created feature: %s", createdFeature.Name)
+	return resourceCalendarFeatureRead(d, meta)
+}
+
+func resourceCalendarFeatureRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	var feature *directory.Feature
+	err = retry(func() error {
+		feature, err = config.directory.Resources.Features.Get(customerID, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Feature %q", d.Id()))
+	}
+
+	d.SetId(feature.Name)
+	d.Set("name", feature.Name)
+	d.Set("etags", feature.Etags)
+
+	return nil
+}
+
+func resourceCalendarFeatureUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("name") {
+		oldName, newName := d.GetChange("name")
+		log.Printf("[DEBUG] Renaming feature %s to %s", oldName.(string), newName.(string))
+
+		rename := &directory.FeatureRename{
+			NewName: newName.(string),
+		}
+
+		err = retry(func() error {
+			err = config.directory.Resources.Features.Rename(customerID, oldName.(string), rename).Do()
+			return err
+		}, config.TimeoutMinutes)
+
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error renaming feature %s: %s", oldName.(string), err)
+		}
+
+		// The feature can take a moment to be found under its new name, reading
+		// it too early would drop it from the state
+		err = retryReadAfterWrite(func() error {
+			return retry(func() error {
+				_, err = config.directory.Resources.Features.Get(customerID, newName.(string)).Do()
+				return err
+			}, config.TimeoutMinutes)
+		})
+
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error reading renamed feature %s: %s", newName.(string), err)
+		}
+
+		d.SetId(newName.(string))
+		log.Printf("[INFO] Renamed feature: %s", newName.(string))
+	}
+
+	return resourceCalendarFeatureRead(d, meta)
+}
+
+func resourceCalendarFeatureDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		err = config.directory.Resources.Features.Delete(customerID, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting feature: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
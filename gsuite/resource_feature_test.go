@@ -0,0 +1,87 @@
+package gsuite
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestResourceCalendarFeatureUpdate_rename(t *testing.T) {
+	defer func(backoff time.Duration) { readAfterWriteBackoff = backoff }(readAfterWriteBackoff)
+	readAfterWriteBackoff = time.Millisecond
+
+	calls := []string{}
+	renamed := false
+	meta := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		// my_customer is resolved through the impersonated user
+		if strings.HasSuffix(r.URL.Path, "/users/admin@domain.ext") {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"customerId":"C0123abcd"}`)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/customer/C0123abcd/resources/features/Whiteboard/rename"):
+			renamed = true
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/customer/C0123abcd/resources/features/Smartboard"):
+			// The renamed feature is not found right away
+			if !renamed || len(calls) < 3 {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"code":404,"message":"Resource Not Found: Smartboard"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"name":"Smartboard","etags":"etag"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"code":400,"message":"Unexpected call"}}`)
+		}
+	})
+	meta.CustomerId = "my_customer"
+	meta.ImpersonatedUserEmail = "admin@domain.ext"
+
+	state := &terraform.InstanceState{
+		ID: "Whiteboard",
+		Attributes: map[string]string{
+			"id":    "Whiteboard",
+			"name":  "Whiteboard",
+			"etags": "etag",
+		},
+	}
+
+	r := resourceCalendarFeature()
+	diff, err := r.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "Smartboard",
+	}), meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("expected the feature to be renamed in place, got %#v", diff)
+	}
+
+	newState, err := r.Apply(state, diff, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if newState == nil || newState.ID != "Smartboard" || newState.Attributes["name"] != "Smartboard" {
+		t.Fatalf("expected the feature to be kept as Smartboard, got %#v", newState)
+	}
+
+	if !renamed || !strings.Contains(calls[0], `{"newName":"Smartboard"}`) {
+		t.Fatalf("expected the feature to be renamed, got calls %v", calls)
+	}
+	for _, call := range calls {
+		if strings.HasPrefix(call, http.MethodDelete+" ") || strings.HasSuffix(strings.Fields(call)[1], "/features") {
+			t.Errorf("expected the feature not to be recreated, got call %s", call)
+		}
+	}
+}
@@ -20,20 +20,25 @@ func resourceGroup() *schema.Resource {
 			State: resourceGroupImporter,
 		},
 
+		CustomizeDiff: resourceGroupCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"email": {
-				Type:     schema.TypeString,
-				Required: true,
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
-				ValidateFunc: validateEmail,
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
 			},
 
 			"aliases": {
 				Type:     schema.TypeList,
 				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					StateFunc:        lowercaseEmail,
+					DiffSuppressFunc: emailDiffSuppress,
+				},
 			},
 
 			"name": {
@@ -56,6 +61,8 @@ func resourceGroup() *schema.Resource {
 				Computed: true,
 			},
 
+			// Aliases derived from the domain aliases of the customer, these are
+			// read only and never part of aliases
 			"non_editable_aliases": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -84,7 +91,7 @@ func resourceGroupCreate(d *schema.ResourceData, meta interface{}) error {
 
 	var createdGroup *directory.Group
 	var err error
-	err = retry(func() error {
+	err = retryPassDuplicate(func() error {
 		createdGroup, err = config.directory.Groups.Insert(group).Do()
 		return err
 	}, config.TimeoutMinutes)
@@ -93,9 +100,20 @@ func resourceGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	time.Sleep(time.Second * 1)
 
 	if err != nil {
-		if config.UpdateExisting && strings.Contains(fmt.Sprintf("%s", err), "Entity already exists.") {
-			log.Printf("[INFO] Group already exists, overwriting existing values")
-			d.SetId(d.Get("email").(string))
+		if config.UpdateExisting && isDuplicateError(err) {
+			log.Printf("[INFO] Group %s already exists, adopting it and overwriting existing values", group.Email)
+
+			var existingGroup *directory.Group
+			err = retry(func() error {
+				existingGroup, err = config.directory.Groups.Get(group.Email).Do()
+				return err
+			}, config.TimeoutMinutes)
+
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error fetching existing group %s: %s", group.Email, err)
+			}
+
+			d.SetId(existingGroup.Id)
 			return resourceGroupUpdate(d, meta)
 		}
 		return fmt.Errorf("[ERROR] Error creating group: %s", err)
@@ -103,10 +121,12 @@ func resourceGroupCreate(d *schema.ResourceData, meta interface{}) error {
 
 	// Try to read the group, retrying for 404's, this makes sure the group has been
 	// created before we try to use it for follow-up actions (like adding aliases)
-	err = retryNotFound(func() error {
-		group, err = config.directory.Groups.Get(createdGroup.Id).Do()
-		return err
-	}, config.TimeoutMinutes)
+	err = retryReadAfterWrite(func() error {
+		return retry(func() error {
+			group, err = config.directory.Groups.Get(createdGroup.Id).Do()
+			return err
+		}, config.TimeoutMinutes)
+	})
 
 	if err != nil {
 		return fmt.Errorf("[ERROR] Taking too long to create this group: %s", err)
@@ -135,6 +155,28 @@ func resourceGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	return resourceGroupRead(d, meta)
 }
 
+func resourceGroupCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("aliases") {
+		if err := validateGroupAliases(d); err != nil {
+			return err
+		}
+	}
+	return checkEmailDomain(d, "email", meta.(*Config))
+}
+
+// validateGroupAliases checks that none of the configured aliases is one of
+// the non-editable aliases of the group, which the API refuses to manage.
+func validateGroupAliases(d *schema.ResourceDiff) error {
+	for _, nonEditable := range d.Get("non_editable_aliases").([]interface{}) {
+		for _, alias := range d.Get("aliases").([]interface{}) {
+			if strings.EqualFold(alias.(string), nonEditable.(string)) {
+				return fmt.Errorf("[ERROR] Alias %s is a non-editable alias of the group, derived from a domain alias, remove it from aliases", alias)
+			}
+		}
+	}
+	return nil
+}
+
 func resourceGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
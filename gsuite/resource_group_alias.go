@@ -0,0 +1,169 @@
+package gsuite
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceGroupAlias() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGroupAliasCreate,
+		Read:   resourceGroupAliasRead,
+		Delete: resourceGroupAliasDelete,
+		// Aliases can not be renamed, there is no update method
+		Importer: &schema.ResourceImporter{
+			State: resourceGroupAliasImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
+			},
+
+			"alias": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
+			},
+		},
+	}
+}
+
+// groupAliasExists looks the alias up in the list of aliases of the group.
+func groupAliasExists(config *Config, groupEmail, alias string) (bool, error) {
+	aliasesResponse, err := config.directory.Groups.Aliases.List(groupEmail).Do()
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range aliasesResponse.Aliases {
+		if c, ok := v.(map[string]interface{}); ok && strings.ToLower(c["alias"].(string)) == alias {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func resourceGroupAliasCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	groupEmail := strings.ToLower(d.Get("group_email").(string))
+	alias := &directory.Alias{
+		Alias: strings.ToLower(d.Get("alias").(string)),
+	}
+
+	var createdAlias *directory.Alias
+	var err error
+	err = retry(func() error {
+		createdAlias, err = config.directory.Groups.Aliases.Insert(groupEmail, alias).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating group alias: %s", err)
+	}
+
+	// Wait for the alias to show up in the list, so a plan right after the
+	// apply doesn't consider it gone
+	err = retryNotFound(func() error {
+		exists, err := groupAliasExists(config, groupEmail, alias.Alias)
+		if err == nil && !exists {
+			return errors.New("Eventual consistency. Please try again")
+		}
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Taking too long to create this group alias: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", groupEmail, createdAlias.Alias))
+	log.Printf("[INFO] Created group alias: %s", createdAlias.Alias)
+	return resourceGroupAliasRead(d, meta)
+}
+
+func resourceGroupAliasRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	groupEmail := strings.ToLower(d.Get("group_email").(string))
+	alias := strings.ToLower(d.Get("alias").(string))
+
+	var exists bool
+	var err error
+	err = retry(func() error {
+		exists, err = groupAliasExists(config, groupEmail, alias)
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Group alias %q", alias))
+	}
+
+	if !exists {
+		log.Printf("[WARN] Removing group alias %q because it's gone", alias)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("group_email", groupEmail)
+	d.Set("alias", alias)
+
+	return nil
+}
+
+func resourceGroupAliasDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	groupEmail := strings.ToLower(d.Get("group_email").(string))
+	alias := strings.ToLower(d.Get("alias").(string))
+
+	var err error
+	err = retry(func() error {
+		err = config.directory.Groups.Aliases.Delete(groupEmail, alias).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Group alias %q is already gone", alias)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error deleting group alias: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using [group email]{:,/}[alias]
+func resourceGroupAliasImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), ":")
+	if len(s) < 2 {
+		s = strings.Split(d.Id(), "/")
+	}
+
+	if len(s) < 2 {
+		return nil, fmt.Errorf("[WARN] Import via [group email]:[alias] or [group email]/[alias]")
+	}
+	groupEmail, alias := strings.ToLower(s[0]), strings.ToLower(s[1])
+
+	d.SetId(fmt.Sprintf("%s/%s", groupEmail, alias))
+	d.Set("group_email", groupEmail)
+	d.Set("alias", alias)
+
+	return []*schema.ResourceData{d}, nil
+}
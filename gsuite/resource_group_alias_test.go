@@ -0,0 +1,95 @@
+package gsuite
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceGroupAlias_basic(t *testing.T) {
+	domainName := os.Getenv(testAccDomainEnvVar)
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if domainName == "" {
+				t.Skipf("%s must be set for group alias acceptance tests", testAccDomainEnvVar)
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGroupAliasDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupAliasConfig(name, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGroupAliasExists("gsuite_group_alias.test"),
+					resource.TestCheckResourceAttr("gsuite_group_alias.test", "alias", fmt.Sprintf("%s-alias@%s", name, domainName)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGroupAliasExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		exists, err := groupAliasExists(config, rs.Primary.Attributes["group_email"], rs.Primary.Attributes["alias"])
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Group alias %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGroupAliasDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gsuite_group_alias" {
+			continue
+		}
+
+		exists, err := groupAliasExists(config, rs.Primary.Attributes["group_email"], rs.Primary.Attributes["alias"])
+		// The group is destroyed together with the alias
+		if err != nil && strings.Contains(err.Error(), "404") {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("Group alias %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccResourceGroupAliasConfig(name, domainName string) string {
+	return fmt.Sprintf(`
+resource "gsuite_group" "test" {
+  email = "%[1]s@%[2]s"
+  name  = "%[1]s"
+}
+
+resource "gsuite_group_alias" "test" {
+  group_email = gsuite_group.test.email
+  alias       = "%[1]s-alias@%[2]s"
+}
+`, name, domainName)
+}
@@ -1,12 +1,16 @@
 package gsuite
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+	groupSettings "google.golang.org/api/groupssettings/v1"
 )
 
 var schemaMember = map[string]*schema.Schema{
@@ -31,36 +35,132 @@ var schemaMember = map[string]*schema.Schema{
 	},
 
 	"role": &schema.Schema{
-		Type:     schema.TypeString,
-		Default:  "MEMBER",
-		Optional: true,
+		Type:         schema.TypeString,
+		Default:      "MEMBER",
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(memberRoles, true),
 	},
 
 	"email": &schema.Schema{
-		Type:     schema.TypeString,
-		Required: true,
-		ForceNew: true,
-		StateFunc: func(val interface{}) string {
-			return strings.ToLower(val.(string))
-		},
-		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-			return strings.ToLower(strings.Trim(old, `"`)) == strings.ToLower(strings.Trim(new, `"`))
-		},
-		ValidateFunc: validateEmail,
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		StateFunc:        lowercaseEmail,
+		DiffSuppressFunc: emailDiffSuppress,
+		ValidateFunc:     validateEmail,
 	},
 }
 
 var schemaGroup = map[string]*schema.Schema{
 	"group": &schema.Schema{
-		Type:     schema.TypeString,
-		Required: true,
-		StateFunc: func(val interface{}) string {
-			return strings.ToLower(val.(string))
-		},
+		Type:             schema.TypeString,
+		Required:         true,
+		StateFunc:        lowercaseEmail,
+		DiffSuppressFunc: emailDiffSuppress,
 	},
 }
 
-var schemaMembership = mergeSchemas(schemaGroup, schemaMember)
+// memberRoles are the roles a member can have in a group.
+var memberRoles = []string{"OWNER", "MANAGER", "MEMBER"}
+
+// memberDeliverySettings are the mail delivery preferences a user can have
+// as member of a group.
+var memberDeliverySettings = []string{"ALL_MAIL", "DAILY", "DIGEST", "DISABLED", "NONE"}
+
+var schemaMemberDeliverySettings = map[string]*schema.Schema{
+	"delivery_settings": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "ALL_MAIL",
+		ValidateFunc: validation.StringInSlice(memberDeliverySettings, false),
+	},
+}
+
+var schemaMembership = mergeSchemas(mergeSchemas(schemaGroup, schemaMember), schemaMemberDeliverySettings)
+
+// memberDeliverySettingsOrDefault returns the delivery settings of a member,
+// the API omits them when the member receives all mail.
+func memberDeliverySettingsOrDefault(member *directory.Member) string {
+	if member.DeliverySettings == "" {
+		return "ALL_MAIL"
+	}
+	return member.DeliverySettings
+}
+
+// memberTypeOf looks up whether the email of a member belongs to a group of
+// the domain, GROUP, or not, USER. An empty type is returned when the group
+// can't be looked up, e.g. because it belongs to another domain.
+func memberTypeOf(email string, config *Config) (string, error) {
+	memberType := "GROUP"
+	err := retry(func() error {
+		_, err := config.directory.Groups.Get(email).Fields("id").Do()
+		if isNotFound(err) {
+			memberType = "USER"
+			return nil
+		}
+		var gerr *googleapi.Error
+		if errors.As(err, &gerr) && gerr.Code == 403 {
+			log.Printf("[WARN] Unable to look up whether member %s is a group: %s", email, err)
+			memberType = ""
+			return nil
+		}
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error looking up whether member %s is a group: %s", email, err)
+	}
+	return memberType, nil
+}
+
+// validateMemberRole checks the role of a member, groups nested in another
+// group can only have the MEMBER role.
+func validateMemberRole(email, memberType, role string) error {
+	if memberType == "GROUP" && !strings.EqualFold(role, "MEMBER") {
+		return fmt.Errorf("[ERROR] Group %s can only be nested in another group with the MEMBER role, got %s", email, role)
+	}
+	return nil
+}
+
+// isExternalMember reports whether the email of a member is outside of the
+// domains of the customer.
+func isExternalMember(email string, domains map[string]bool) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	return !domains[strings.ToLower(email[at+1:])]
+}
+
+// checkExternalMember explains why a member from outside of the domains of
+// the customer can't be added to the group, before the API refuses it with a
+// generic error. The check is skipped when the domains or the settings of the
+// group can't be read.
+func checkExternalMember(groupEmail, email string, config *Config) error {
+	domains, err := config.customerDomains()
+	if err != nil {
+		log.Printf("[WARN] Unable to check whether member %s is external: %s", email, err)
+		return nil
+	}
+	if !isExternalMember(email, domains) {
+		return nil
+	}
+
+	var settings *groupSettings.Groups
+	err = retry(func() error {
+		settings, err = config.groupSettings.Groups.Get(groupEmail).Fields("allowExternalMembers").Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		log.Printf("[WARN] Unable to check whether group %s allows external members: %s", groupEmail, err)
+		return nil
+	}
+	if settings.AllowExternalMembers == "false" {
+		return fmt.Errorf("[ERROR] Member %s is outside of the domains of the customer and group %s does not allow external members, set allow_external_members to true in the gsuite_group_settings of the group", email, groupEmail)
+	}
+	return nil
+}
 
 func resourceGroupMember() *schema.Resource {
 	return &schema.Resource{
@@ -86,8 +186,27 @@ func resourceGroupMemberCreate(d *schema.ResourceData, meta interface{}) error {
 		Email: strings.ToLower(d.Get("email").(string)),
 	}
 
+	memberType, err := memberTypeOf(groupMember.Email, config)
+	if err != nil {
+		return err
+	}
+	if err := validateMemberRole(groupMember.Email, memberType, groupMember.Role); err != nil {
+		return err
+	}
+	groupMember.Type = memberType
+
+	if memberType != "GROUP" {
+		if err := checkExternalMember(group, groupMember.Email, config); err != nil {
+			return err
+		}
+	}
+
+	// Only send non-default delivery settings, they do not apply to groups
+	if deliverySettings := d.Get("delivery_settings").(string); deliverySettings != "ALL_MAIL" {
+		groupMember.DeliverySettings = deliverySettings
+	}
+
 	var createdGroupMember *directory.Member
-	var err error
 	err = retryPassDuplicate(func() error {
 		createdGroupMember, err = config.directory.Members.Insert(group, groupMember).Do()
 		return err
@@ -128,10 +247,12 @@ func resourceGroupMemberCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// Try to read the group member, retrying for 404's
-	err = retryNotFound(func() error {
-		groupMember, err = config.directory.Members.Get(group, d.Id()).Do()
-		return err
-	}, config.TimeoutMinutes)
+	err = retryReadAfterWrite(func() error {
+		return retry(func() error {
+			groupMember, err = config.directory.Members.Get(group, d.Id()).Do()
+			return err
+		}, config.TimeoutMinutes)
+	})
 
 	if err != nil {
 		return fmt.Errorf("[ERROR] Taking too long to create this group member: %s", err)
@@ -152,10 +273,18 @@ func resourceGroupMemberUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if d.HasChange("role") {
+		if err := validateMemberRole(d.Get("email").(string), d.Get("type").(string), d.Get("role").(string)); err != nil {
+			return err
+		}
 		log.Printf("[DEBUG] Updating groupMember role: %s to %s", d.Get("email").(string), d.Get("role").(string))
 		groupMember.Role = strings.ToUpper(d.Get("role").(string))
 	}
 
+	if d.HasChange("delivery_settings") {
+		log.Printf("[DEBUG] Updating groupMember delivery_settings: %s to %s", d.Get("email").(string), d.Get("delivery_settings").(string))
+		groupMember.DeliverySettings = d.Get("delivery_settings").(string)
+	}
+
 	if len(nullFields) > 0 {
 		groupMember.NullFields = nullFields
 	}
@@ -192,6 +321,7 @@ func resourceGroupMemberRead(d *schema.ResourceData, meta interface{}) error {
 	d.SetId(groupMember.Id)
 	d.Set("role", strings.ToUpper(groupMember.Role))
 	d.Set("email", strings.ToLower(groupMember.Email))
+	d.Set("delivery_settings", memberDeliverySettingsOrDefault(groupMember))
 	d.Set("etag", groupMember.Etag)
 	d.Set("kind", groupMember.Kind)
 	d.Set("status", groupMember.Status)
@@ -216,20 +346,16 @@ func resourceGroupMemberDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-// Allow importing using [group]{:,/}[email]
+// Allow importing using [group]{:,/}[member email or ID]
 func resourceGroupMemberImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	config := meta.(*Config)
 
-	s := strings.Split(d.Id(), ":")
-	if len(s) < 2 {
-		s = strings.Split(d.Id(), "/")
-	}
-
-	if len(s) < 2 {
-		return nil, fmt.Errorf("[WARN] Import via [group]:[member email] or [group]/[member email]")
+	group, member, err := parseGroupMemberImportID(d.Id())
+	if err != nil {
+		return nil, err
 	}
-	group, member := strings.ToLower(s[0]), strings.ToLower(s[1])
 
+	// The member key is either the email or the unique ID of the member
 	id, err := config.directory.Members.Get(group, member).Do()
 
 	if err != nil {
@@ -238,8 +364,9 @@ func resourceGroupMemberImporter(d *schema.ResourceData, meta interface{}) ([]*s
 
 	d.SetId(id.Id)
 	d.Set("group", group)
-	d.Set("role", id.Role)
-	d.Set("email", id.Email)
+	d.Set("role", strings.ToUpper(id.Role))
+	d.Set("email", strings.ToLower(id.Email))
+	d.Set("delivery_settings", memberDeliverySettingsOrDefault(id))
 	d.Set("etag", id.Etag)
 	d.Set("kind", id.Kind)
 	d.Set("status", id.Status)
@@ -247,3 +374,18 @@ func resourceGroupMemberImporter(d *schema.ResourceData, meta interface{}) ([]*s
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// parseGroupMemberImportID splits an import ID of the form [group]:[member] or
+// [group]/[member] into the lowercased group and member keys.
+func parseGroupMemberImportID(importID string) (string, string, error) {
+	s := strings.Split(importID, ":")
+	if len(s) != 2 {
+		s = strings.Split(importID, "/")
+	}
+
+	if len(s) != 2 || strings.TrimSpace(s[0]) == "" || strings.TrimSpace(s[1]) == "" {
+		return "", "", fmt.Errorf("[WARN] Unexpected import ID %q, import via [group]:[member email or ID] or [group]/[member email or ID]", importID)
+	}
+
+	return strings.ToLower(strings.TrimSpace(s[0])), strings.ToLower(strings.TrimSpace(s[1])), nil
+}
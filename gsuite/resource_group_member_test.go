@@ -0,0 +1,334 @@
+package gsuite
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestParseGroupMemberImportID(t *testing.T) {
+	testCases := []struct {
+		importID string
+		group    string
+		member   string
+		valid    bool
+	}{
+		{"group@domain.ext/member@domain.ext", "group@domain.ext", "member@domain.ext", true},
+		{"Group@Domain.ext:Member@Domain.ext", "group@domain.ext", "member@domain.ext", true},
+		{"group@domain.ext/123456789", "group@domain.ext", "123456789", true},
+		{"group@domain.ext", "", "", false},
+		{"group@domain.ext/", "", "", false},
+		{"/member@domain.ext", "", "", false},
+		{"group@domain.ext/member@domain.ext/extra", "", "", false},
+	}
+
+	for _, testCase := range testCases {
+		group, member, err := parseGroupMemberImportID(testCase.importID)
+		if !testCase.valid {
+			if err == nil {
+				t.Errorf("expected an error for %q", testCase.importID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", testCase.importID, err)
+			continue
+		}
+		if group != testCase.group || member != testCase.member {
+			t.Errorf("expected %q to be split into %q and %q, got %q and %q", testCase.importID, testCase.group, testCase.member, group, member)
+		}
+	}
+}
+
+func TestResourceGroupMemberImporter_memberID(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/groups/group@domain.ext/members/123456789") {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"123456789","email":"Member@Domain.ext","role":"manager","type":"USER","status":"ACTIVE"}`)
+	})
+
+	d := resourceGroupMember().TestResourceData()
+	d.SetId("group@domain.ext/123456789")
+	imported, err := resourceGroupMemberImporter(d, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	d = imported[0]
+	expected := map[string]string{
+		"group":             "group@domain.ext",
+		"email":             "member@domain.ext",
+		"role":              "MANAGER",
+		"delivery_settings": "ALL_MAIL",
+	}
+	if d.Id() != "123456789" {
+		t.Errorf("expected the ID of the member, got %q", d.Id())
+	}
+	for k, v := range expected {
+		if actual := d.Get(k).(string); actual != v {
+			t.Errorf("expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+}
+
+func TestAccResourceGroupMember_import(t *testing.T) {
+	domainName := os.Getenv(testAccDomainEnvVar)
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if domainName == "" {
+				t.Skipf("%s must be set for group member acceptance tests", testAccDomainEnvVar)
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupMemberConfig(name, domainName),
+			},
+			{
+				ResourceName:      "gsuite_group_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccGroupMemberImportID("gsuite_group_member.test", "email"),
+			},
+			{
+				ResourceName:      "gsuite_group_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccGroupMemberImportID("gsuite_group_member.test", "id"),
+			},
+		},
+	})
+}
+
+// testAccGroupMemberImportID builds the import ID of a group member, keyed by
+// the given attribute of the member.
+func testAccGroupMemberImportID(n, memberKey string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["group"], rs.Primary.Attributes[memberKey]), nil
+	}
+}
+
+func testAccResourceGroupMemberConfig(name, domainName string) string {
+	return fmt.Sprintf(`
+resource "gsuite_group" "test" {
+  email = "%[1]s@%[2]s"
+  name  = "%[1]s"
+}
+
+resource "gsuite_group" "member" {
+  email = "%[1]s-member@%[2]s"
+  name  = "%[1]s-member"
+}
+
+resource "gsuite_group_member" "test" {
+  group = gsuite_group.test.email
+  email = gsuite_group.member.email
+  role  = "MEMBER"
+}
+`, name, domainName)
+}
+
+// testNestedGroupServer serves the parent@domain.ext group, child@domain.ext is
+// a group and user@domain.ext a user. Member inserts are recorded.
+func testNestedGroupServer(t *testing.T) (*Config, *[]string) {
+	var inserts []string
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/groups/child@domain.ext"):
+			fmt.Fprint(w, `{"id":"child-id"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/groups/user@domain.ext"):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":404,"message":"Resource Not Found: groupKey"}}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/groups/parent@domain.ext/members"):
+			body, _ := ioutil.ReadAll(r.Body)
+			inserts = append(inserts, string(body))
+			fmt.Fprint(w, `{"id":"child-id","email":"child@domain.ext","role":"MEMBER","type":"GROUP"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/groups/parent@domain.ext/members/child-id"):
+			fmt.Fprint(w, `{"id":"child-id","email":"child@domain.ext","role":"MEMBER","type":"GROUP"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/groups/parent@domain.ext/members/child@domain.ext"):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":404,"message":"Resource Not Found: memberKey"}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	return config, &inserts
+}
+
+func TestResourceGroupMemberCreate_nestedGroup(t *testing.T) {
+	config, inserts := testNestedGroupServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceGroupMember().Schema, map[string]interface{}{
+		"group": "parent@domain.ext",
+		"email": "Child@Domain.ext",
+		"role":  "member",
+	})
+	if err := resourceGroupMemberCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(*inserts) != 1 || !strings.Contains((*inserts)[0], `"type":"GROUP"`) || !strings.Contains((*inserts)[0], `"role":"MEMBER"`) {
+		t.Fatalf("expected the group to be inserted as member of type GROUP, got %v", *inserts)
+	}
+	if d.Id() != "child-id" || d.Get("type").(string) != "GROUP" {
+		t.Errorf("expected the nested group to be read back with type GROUP, got %q and %q", d.Id(), d.Get("type"))
+	}
+}
+
+func TestResourceGroupMemberCreate_nestedGroupRole(t *testing.T) {
+	config, inserts := testNestedGroupServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceGroupMember().Schema, map[string]interface{}{
+		"group": "parent@domain.ext",
+		"email": "child@domain.ext",
+		"role":  "OWNER",
+	})
+	err := resourceGroupMemberCreate(d, config)
+	if err == nil || !strings.Contains(err.Error(), "MEMBER role") {
+		t.Fatalf("expected an error about the role of the nested group, got %v", err)
+	}
+	if len(*inserts) != 0 {
+		t.Errorf("expected the group not to be inserted, got %v", *inserts)
+	}
+}
+
+func TestIsExternalMember(t *testing.T) {
+	domains := map[string]bool{"domain.ext": true, "domain-alias.ext": true}
+
+	testCases := []struct {
+		email    string
+		external bool
+	}{
+		{"user@domain.ext", false},
+		{"User@Domain-Alias.ext", false},
+		{"user@other.ext", true},
+		{"user@sub.domain.ext", true},
+		{"user@domain.ext.other.ext", true},
+	}
+
+	for _, testCase := range testCases {
+		if got := isExternalMember(testCase.email, domains); got != testCase.external {
+			t.Errorf("expected isExternalMember(%s) to be %t, got %t", testCase.email, testCase.external, got)
+		}
+	}
+}
+
+// testExternalMemberServer serves the domains of the customer and the settings
+// of group@domain.ext, which allows external members or not. Member inserts
+// are recorded.
+func testExternalMemberServer(t *testing.T, allowExternalMembers string) (*Config, *[]string) {
+	var inserts []string
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/customer/my_customer/domains"):
+			fmt.Fprint(w, `{"domains":[{"domainName":"domain.ext","domainAliases":[{"domainAliasName":"domain-alias.ext"}]}]}`)
+		case !strings.HasPrefix(r.URL.Path, "/admin/directory/v1/"):
+			fmt.Fprintf(w, `{"allowExternalMembers":%q}`, allowExternalMembers)
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/groups/") && !strings.Contains(r.URL.Path, "/members"):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":404,"message":"Resource Not Found: groupKey"}}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/groups/group@domain.ext/members"):
+			body, _ := ioutil.ReadAll(r.Body)
+			inserts = append(inserts, string(body))
+			fmt.Fprint(w, `{"id":"member-id","email":"user@other.ext","role":"MEMBER","type":"USER"}`)
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"id":"member-id","email":"user@other.ext","role":"MEMBER","type":"USER"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	config.CustomerId = "my_customer"
+	config.customerDomainsCache = &customerDomainsCache{}
+
+	return config, &inserts
+}
+
+func TestResourceGroupMemberCreate_externalMember(t *testing.T) {
+	config, inserts := testExternalMemberServer(t, "false")
+
+	d := schema.TestResourceDataRaw(t, resourceGroupMember().Schema, map[string]interface{}{
+		"group": "group@domain.ext",
+		"email": "user@other.ext",
+	})
+	err := resourceGroupMemberCreate(d, config)
+	if err == nil || !strings.Contains(err.Error(), "does not allow external members") {
+		t.Fatalf("expected an error about external members, got %v", err)
+	}
+	if len(*inserts) > 0 {
+		t.Fatalf("expected no member to be inserted, got %v", *inserts)
+	}
+
+	// Members of the domain aliases are not external
+	if err := checkExternalMember("group@domain.ext", "user@domain-alias.ext", config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestResourceGroupMemberCreate_externalMemberAllowed(t *testing.T) {
+	config, inserts := testExternalMemberServer(t, "true")
+
+	d := schema.TestResourceDataRaw(t, resourceGroupMember().Schema, map[string]interface{}{
+		"group": "group@domain.ext",
+		"email": "user@other.ext",
+	})
+	if err := resourceGroupMemberCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(*inserts) != 1 {
+		t.Fatalf("expected the external member to be inserted, got %v", *inserts)
+	}
+}
+
+func TestResourceGroupMemberRead_status(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/groups/list@domain.ext/members/member-id") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"member-id","email":"user@domain.ext","role":"MEMBER","type":"USER","status":"SUSPENDED"}`)
+	})
+
+	r := resourceGroupMember()
+	d := r.Data(nil)
+	d.SetId("member-id")
+	d.Set("group", "list@domain.ext")
+	if err := resourceGroupMemberRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if status := d.Get("status").(string); status != "SUSPENDED" {
+		t.Fatalf("expected status SUSPENDED, got %q", status)
+	}
+
+	// The status is only read, it never shows up in a plan
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"group": "list@domain.ext",
+		"email": "user@domain.ext",
+	}), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff, got %#v", diff.Attributes)
+	}
+}
@@ -3,22 +3,36 @@ package gsuite
 import (
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	directory "google.golang.org/api/admin/directory/v1"
 	"google.golang.org/api/googleapi"
 )
 
 var schemaGroupMembersEmail = map[string]*schema.Schema{
 	"email": &schema.Schema{
-		Type:     schema.TypeString,
-		Required: true,
-		ForceNew: false,
-		StateFunc: func(val interface{}) string {
-			return strings.ToLower(val.(string))
-		},
-		ValidateFunc: validateEmail,
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         false,
+		StateFunc:        lowercaseEmail,
+		DiffSuppressFunc: emailDiffSuppress,
+		ValidateFunc:     validateEmail,
+	},
+
+	// The delivery settings the API returns win, so changes outside of
+	// terraform show up. The members list API doesn't always return them,
+	// those members keep the value from state, or ALL_MAIL.
+	"delivery_settings": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "ALL_MAIL",
+		ValidateFunc: validation.StringInSlice(memberDeliverySettings, false),
 	},
 }
 
@@ -34,14 +48,22 @@ func resourceGroupMembers() *schema.Resource {
 			State: resourceGroupMembersImporter,
 		},
 
+		// Large groups need many API calls, the timeouts bound the whole
+		// operation while timeout_minutes bounds the retries of a single call
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"group_email": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
 			},
 			"member": {
 				Type:     schema.TypeSet,
@@ -50,30 +72,55 @@ func resourceGroupMembers() *schema.Resource {
 					Schema: schemaGroupMembers,
 				},
 			},
+			"ignore_unmanaged_members": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Refuse changes which leave the group without owners, instead of
+			// only warning about them
+			"require_owner": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
 func resourceGroupMembersRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG]: Reading gsuite_group_members")
-	config := meta.(*Config)
+	config, err := meta.(*Config).withTimeout("Reading gsuite_group_members", d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		return err
+	}
 
 	groupEmail := d.Id()
 
 	members, err := getAPIMembers(groupEmail, config)
 
 	if err != nil {
-		return err
+		return handleNotFoundError(err, d, fmt.Sprintf("Members of group %q", groupEmail))
+	}
+
+	stateMembers := d.Get("member").(*schema.Set).List()
+	if d.Get("ignore_unmanaged_members").(bool) && len(stateMembers) > 0 {
+		members = filterManagedMembers(members, stateMembers)
 	}
 
 	d.Set("group_email", strings.ToLower(groupEmail))
-	d.Set("member", membersToCfg(members))
+	d.Set("member", mergeMemberDeliverySettings(membersToCfg(members), stateMembers))
 	return nil
 }
 
 func resourceGroupMembersCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG]: Creating gsuite_group_members")
-	gid, err := createOrUpdateGroupMembers(d, meta)
+	config, err := meta.(*Config).withTimeout("Creating gsuite_group_members", d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
+	gid, err := createOrUpdateGroupMembers(d, config)
 
 	if err != nil {
 		return err
@@ -85,7 +132,12 @@ func resourceGroupMembersCreate(d *schema.ResourceData, meta interface{}) error
 
 func resourceGroupMembersUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG]: Updating gsuite_group_members")
-	_, err := createOrUpdateGroupMembers(d, meta)
+	config, err := meta.(*Config).withTimeout("Updating gsuite_group_members", d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return err
+	}
+
+	_, err = createOrUpdateGroupMembers(d, config)
 
 	if err != nil {
 		return err
@@ -95,17 +147,45 @@ func resourceGroupMembersUpdate(d *schema.ResourceData, meta interface{}) error
 
 func resourceGroupMembersDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG]: Deleting gsuite_group_members")
-	config := meta.(*Config)
+	config, err := meta.(*Config).withTimeout("Deleting gsuite_group_members", d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return err
+	}
 
+	var deleted []string
 	for _, rawMember := range d.Get("member").(*schema.Set).List() {
-		member := rawMember.(map[string]interface{})
-		deleteMember(member["email"].(string), d.Id(), config)
+		deleted = append(deleted, strings.ToLower(rawMember.(map[string]interface{})["email"].(string)))
+	}
+	sort.Strings(deleted)
+
+	if err := deleteMembers(d.Id(), deleted, config); err != nil {
+		return err
 	}
 
 	d.SetId("")
 	return nil
 }
 
+// deleteMembers removes the members of a group, many of them using batch
+// requests like when reconciling. Errors of single members don't stop the
+// others from being removed, they are returned together.
+func deleteMembers(groupEmail string, emails []string, config *Config) error {
+	if len(emails) > 1 {
+		emails = batchDeleteMembers(groupEmail, emails, config)
+	}
+
+	var errs []string
+	for _, email := range emails {
+		if err := deleteMember(email, groupEmail, config); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
 func membersToCfg(members []*directory.Member) []map[string]interface{} {
 	if members == nil {
 		return nil
@@ -115,18 +195,115 @@ func membersToCfg(members []*directory.Member) []map[string]interface{} {
 
 	for _, m := range members {
 		finalMembers = append(finalMembers, map[string]interface{}{
-			"email":  m.Email,
-			"etag":   m.Etag,
-			"kind":   m.Kind,
-			"status": m.Status,
-			"type":   m.Type,
-			"role":   m.Role,
+			"email":             m.Email,
+			"etag":              m.Etag,
+			"kind":              m.Kind,
+			"status":            m.Status,
+			"type":              m.Type,
+			"role":              m.Role,
+			"delivery_settings": m.DeliverySettings,
 		})
 	}
 
 	return finalMembers
 }
 
+// filterManagedMembers only keeps the members that are known in state, members
+// added out of band (like service accounts) are left alone this way.
+func filterManagedMembers(members []*directory.Member, stateMembers []interface{}) []*directory.Member {
+	managed := make(map[string]bool)
+	for _, rawMember := range stateMembers {
+		member := rawMember.(map[string]interface{})
+		managed[strings.ToLower(member["email"].(string))] = true
+	}
+
+	filtered := make([]*directory.Member, 0, len(members))
+	for _, m := range members {
+		if managed[strings.ToLower(m.Email)] {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+// excludeMembers returns the members which are not part of excluded.
+func excludeMembers(members, excluded []*directory.Member) []*directory.Member {
+	skip := make(map[string]bool)
+	for _, m := range excluded {
+		skip[strings.ToLower(m.Email)] = true
+	}
+
+	kept := make([]*directory.Member, 0, len(members))
+	for _, m := range members {
+		if !skip[strings.ToLower(m.Email)] {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// desiredOwners lists the owners of a group after reconciling its members:
+// the configured owners and the unmanaged owners, which are left alone.
+func desiredOwners(cfgMembers []map[string]interface{}, unmanagedMembers []*directory.Member) []string {
+	configured := make(map[string]bool)
+	var owners []string
+	for _, member := range cfgMembers {
+		email := strings.ToLower(member["email"].(string))
+		configured[email] = true
+		if strings.EqualFold(member["role"].(string), "OWNER") {
+			owners = append(owners, email)
+		}
+	}
+
+	for _, m := range unmanagedMembers {
+		email := strings.ToLower(m.Email)
+		if !configured[email] && strings.EqualFold(m.Role, "OWNER") {
+			owners = append(owners, email)
+		}
+	}
+	return owners
+}
+
+// checkGroupOwners warns when the members would leave the group without
+// owners, which orphans it. With requireOwner set this is an error instead,
+// returned before any member is changed.
+func checkGroupOwners(groupEmail string, cfgMembers []map[string]interface{}, unmanagedMembers []*directory.Member, requireOwner bool) error {
+	if len(desiredOwners(cfgMembers, unmanagedMembers)) > 0 {
+		return nil
+	}
+
+	if requireOwner {
+		return fmt.Errorf("[ERROR] Refusing to update the members of %s, the group would be left without an OWNER", groupEmail)
+	}
+	log.Printf("[WARN] The group %s is left without an OWNER, set require_owner to refuse this", groupEmail)
+	return nil
+}
+
+// mergeMemberDeliverySettings fills in the delivery settings the members list
+// API does not return from state, falling back to the API default. Delivery
+// settings the API returns are kept, so changes outside of terraform show up.
+func mergeMemberDeliverySettings(members []map[string]interface{}, stateMembers []interface{}) []map[string]interface{} {
+	deliverySettings := make(map[string]string)
+	for _, rawMember := range stateMembers {
+		member := rawMember.(map[string]interface{})
+		if v, ok := member["delivery_settings"].(string); ok && v != "" {
+			deliverySettings[strings.ToLower(member["email"].(string))] = v
+		}
+	}
+
+	for _, member := range members {
+		if member["delivery_settings"] != "" {
+			continue
+		}
+		if v, ok := deliverySettings[strings.ToLower(member["email"].(string))]; ok {
+			member["delivery_settings"] = v
+		} else {
+			member["delivery_settings"] = "ALL_MAIL"
+		}
+	}
+	return members
+}
+
 func resourceMembers(d *schema.ResourceData) (members []map[string]interface{}) {
 	for _, rawMember := range d.Get("member").(*schema.Set).List() {
 		member := rawMember.(map[string]interface{})
@@ -147,9 +324,23 @@ func createOrUpdateGroupMembers(d *schema.ResourceData, meta interface{}) (strin
 	if err != nil {
 		return groupEmail, fmt.Errorf("[ERROR] Error updating memberships: %v", err)
 	}
+
+	oldMembers, _ := d.GetChange("member")
+	stateMembers := oldMembers.(*schema.Set).List()
+	var unmanagedMembers []*directory.Member
+	if d.Get("ignore_unmanaged_members").(bool) {
+		managedMembers := filterManagedMembers(apiMembers, stateMembers)
+		unmanagedMembers = excludeMembers(apiMembers, managedMembers)
+		apiMembers = managedMembers
+	}
+
+	if err := checkGroupOwners(groupEmail, cfgMembers, unmanagedMembers, d.Get("require_owner").(bool)); err != nil {
+		return groupEmail, err
+	}
+
 	// This call removes any members that aren't defined in cfgMembers,
 	// and adds all of those that are
-	err = reconcileMembers(d, cfgMembers, membersToCfg(apiMembers), config, groupEmail)
+	err = reconcileMembers(d, cfgMembers, mergeMemberDeliverySettings(membersToCfg(apiMembers), stateMembers), config, groupEmail)
 	if err != nil {
 		return groupEmail, fmt.Errorf("[ERROR] Error updating memberships: %v", err)
 	}
@@ -178,24 +369,25 @@ func reconcileMembers(d *schema.ResourceData, cfgMembers, apiMembers []map[strin
 	apiMap := m(apiMembers)
 	log.Println("[DEBUG] Member in API: ", apiMap)
 
-	var cfgRole, apiRole string
+	var cfgRole, apiRole, cfgDeliverySettings, apiDeliverySettings string
+	var deleted []string
 
 	for k, apiMember := range apiMap {
 		if cfgMember, ok := cfgMap[k]; !ok {
 			// The member in the API is not in the config; disable it.
 			log.Printf("[DEBUG] Member in API not in config. Disabling it: %s", k)
-			err := deleteMember(k, gid, config)
-			if err != nil {
-				return err
-			}
+			deleted = append(deleted, k)
 		} else {
 			// The member exists in the config and the API
-			// If role has changed update, otherwise do nothing
+			// If role or delivery settings have changed update, otherwise do nothing
 			cfgRole = strings.ToUpper(cfgMember["role"].(string))
 			apiRole = strings.ToUpper(apiMember["role"].(string))
-			if cfgRole != apiRole {
+			cfgDeliverySettings = cfgMember["delivery_settings"].(string)
+			apiDeliverySettings = apiMember["delivery_settings"].(string)
+			if cfgRole != apiRole || cfgDeliverySettings != apiDeliverySettings {
 				groupMember := &directory.Member{
-					Role: cfgRole,
+					Role:             cfgRole,
+					DeliverySettings: cfgDeliverySettings,
 				}
 
 				var updatedGroupMember *directory.Member
@@ -220,43 +412,163 @@ func reconcileMembers(d *schema.ResourceData, cfgMembers, apiMembers []map[strin
 		}
 	}
 
-	// Upsert memberships which are present in the config, but not in the api
+	// Errors of single members don't stop the others from being handled
+	var errs []string
+
+	// Changes of many members are sent as batch requests, members whose call
+	// failed are handled one by one afterwards, which reports a clear error
+	if err := deleteMembers(gid, deleted, config); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	added := make([]string, 0, len(cfgMap))
 	for email := range cfgMap {
-		err := upsertMember(email, gid, cfgMap[email]["role"].(string), config)
+		added = append(added, email)
+	}
+	sort.Strings(added)
+	if len(added) > 1 {
+		added = batchInsertMembers(gid, added, cfgMap, config)
+	}
+
+	// Upsert memberships which are present in the config, but not in the api
+	for _, email := range added {
+		err := upsertMember(email, gid, cfgMap[email]["role"].(string), cfgMap[email]["delivery_settings"].(string), config)
 		if err != nil {
-			return err
+			errs = append(errs, err.Error())
 		}
 	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
 	return nil
 }
 
+func groupMembersPath(groupEmail string) string {
+	return fmt.Sprintf("/admin/directory/v1/groups/%s/members", url.PathEscape(groupEmail))
+}
+
+// batchDeleteMembers removes the members using batch requests, and returns the
+// members which could not be removed that way.
+func batchDeleteMembers(groupEmail string, emails []string, config *Config) []string {
+	if config.client == nil {
+		return emails
+	}
+
+	calls := make([]batchCall, 0, len(emails))
+	for _, email := range emails {
+		calls = append(calls, batchCall{
+			method: http.MethodDelete,
+			path:   fmt.Sprintf("%s/%s", groupMembersPath(groupEmail), url.PathEscape(email)),
+		})
+	}
+
+	results, err := config.executeBatch(calls)
+	if err != nil {
+		log.Printf("[WARN] Falling back to removing members one by one: %s", err)
+		return emails
+	}
+
+	var failed []string
+	for i, result := range results {
+		if result.err == nil || result.statusCode == http.StatusNotFound {
+			log.Printf("[INFO] Deleted groupMember: %s", emails[i])
+			continue
+		}
+		log.Printf("[DEBUG] Batch call removing member %s failed: %s", emails[i], result.err)
+		failed = append(failed, emails[i])
+	}
+	return failed
+}
+
+// batchInsertMembers adds the members using batch requests, and returns the
+// members which could not be added that way. Members with delivery settings
+// are always returned, since those only apply to users, which needs to be
+// checked for every member.
+func batchInsertMembers(groupEmail string, emails []string, cfgMap map[string]map[string]interface{}, config *Config) []string {
+	if config.client == nil {
+		return emails
+	}
+
+	var failed, batched []string
+	var calls []batchCall
+	for _, email := range emails {
+		if deliverySettings := cfgMap[email]["delivery_settings"].(string); deliverySettings != "" && deliverySettings != "ALL_MAIL" {
+			failed = append(failed, email)
+			continue
+		}
+		batched = append(batched, email)
+		calls = append(calls, batchCall{
+			method: http.MethodPost,
+			path:   groupMembersPath(groupEmail),
+			body: &directory.Member{
+				Email: email,
+				Role:  strings.ToUpper(cfgMap[email]["role"].(string)),
+			},
+		})
+	}
+
+	if len(calls) == 0 {
+		return failed
+	}
+
+	results, err := config.executeBatch(calls)
+	if err != nil {
+		log.Printf("[WARN] Falling back to adding members one by one: %s", err)
+		return emails
+	}
+
+	for i, result := range results {
+		if result.err == nil {
+			log.Printf("[INFO] Created groupMember: %s", batched[i])
+			continue
+		}
+		// Existing members and members that aren't users are handled by upsertMember
+		log.Printf("[DEBUG] Batch call adding member %s failed: %s", batched[i], result.err)
+		failed = append(failed, batched[i])
+	}
+	return failed
+}
+
 // Retrieve a group's members from the API
 func getAPIMembers(groupEmail string, config *Config) ([]*directory.Member, error) {
-	// Get members from the API
+	return listAPIMembers(groupEmail, "", config)
+}
+
+// Retrieve a group's members from the API, roles is a comma separated list of
+// the roles to return, or empty to return all members
+func listAPIMembers(groupEmail, roles string, config *Config) ([]*directory.Member, error) {
 	groupMembers := make([]*directory.Member, 0)
-	token := ""
-	var membersResponse *directory.Members
-	var err error
-	for paginate := true; paginate; {
+	err := listAPIMembersPages(groupEmail, roles, config, func(members []*directory.Member) error {
+		groupMembers = append(groupMembers, members...)
+		return nil
+	})
+	return groupMembers, err
+}
 
+// listAPIMembersPages calls f with every page of members of a group, roles is
+// a comma separated list of the roles to return, or empty to return all
+// members.
+func listAPIMembersPages(groupEmail, roles string, config *Config, f func([]*directory.Member) error) error {
+	return paginate(func(token string) (string, error) {
+		var membersResponse *directory.Members
+		var err error
 		err = retry(func() error {
-			membersResponse, err = config.directory.Members.List(groupEmail).PageToken(token).Do()
+			call := config.directory.Members.List(groupEmail).MaxResults(200).PageToken(token)
+			if roles != "" {
+				call = call.Roles(roles)
+			}
+			membersResponse, err = call.Do()
 			return err
 		}, config.TimeoutMinutes)
-
 		if err != nil {
-			return groupMembers, err
-		}
-		for _, v := range membersResponse.Members {
-			groupMembers = append(groupMembers, v)
+			return "", err
 		}
-		token = membersResponse.NextPageToken
-		paginate = token != ""
-	}
-	return groupMembers, nil
+		return membersResponse.NextPageToken, f(membersResponse.Members)
+	})
 }
 
-func upsertMember(email, groupEmail, role string, config *Config) error {
+func upsertMember(email, groupEmail, role, deliverySettings string, config *Config) error {
 	var err error
 	groupMember := &directory.Member{
 		Role:  strings.ToUpper(role),
@@ -265,20 +577,16 @@ func upsertMember(email, groupEmail, role string, config *Config) error {
 
 	// Check if the email address belongs to a user, or to a group
 	// we need to make sure, because we need to use different logic
-	var isGroup = true
-	err = retry(func() error {
-		_, err := config.directory.Groups.Get(email).Do()
-		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
-			isGroup = false
-			log.Printf("[DEBUG] Setting isGroup to false for %s after getting a 404", email)
-			return nil
-		}
+	memberType, err := memberTypeOf(email, config)
+	if err != nil {
 		return err
-	}, config.TimeoutMinutes)
+	}
+	groupMember.Type = memberType
+	var isGroup = memberType == "GROUP"
 
 	if isGroup == true {
-		if role != "MEMBER" {
-			return fmt.Errorf("[ERROR] Error creating groupMember (%s): nested groups should be role MEMBER", email)
+		if err := validateMemberRole(email, memberType, role); err != nil {
+			return err
 		}
 
 		var isGroupMember = true
@@ -287,7 +595,7 @@ func upsertMember(email, groupEmail, role string, config *Config) error {
 		err = retry(func() error {
 			_, err := config.directory.Members.Get(groupEmail, email).Do()
 
-			if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			if isNotFound(err) {
 				isGroupMember = false
 				log.Printf("[DEBUG] Setting isGroupMember to false for %s after getting a 404", email)
 				return nil
@@ -321,6 +629,9 @@ func upsertMember(email, groupEmail, role string, config *Config) error {
 	}
 
 	if isGroup == false {
+		// Delivery settings only apply to users
+		groupMember.DeliverySettings = deliverySettings
+
 		// Basically the same check as group, but using a more apt method "HasMember"
 		// specifically meant for users
 		var hasMemberResponse *directory.MembersHasMember
@@ -361,6 +672,10 @@ func upsertMember(email, groupEmail, role string, config *Config) error {
 }
 
 func createGroupMember(groupMember *directory.Member, groupEmail string, config *Config) (err error) {
+	if err := checkExternalMember(groupEmail, groupMember.Email, config); err != nil {
+		return err
+	}
+
 	var createdGroupMember *directory.Member
 	err = retry(func() error {
 		createdGroupMember, err = config.directory.Members.Insert(groupEmail, groupMember).Do()
@@ -381,7 +696,11 @@ func deleteMember(email, groupEmail string, config *Config) (err error) {
 	}, config.TimeoutMinutes)
 
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error deleting member: %s", err)
+		if isNotFound(err) {
+			log.Printf("[WARN] Member %s of %s is already gone", email, groupEmail)
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error deleting member %s: %s", email, err)
 	}
 	return nil
 }
@@ -0,0 +1,199 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestListAPIMembers_pages(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/groups/group@domain.ext/members") {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if roles := r.URL.Query().Get("roles"); roles != "OWNER,MANAGER" {
+			t.Errorf("expected the roles to be sent on every page, got %q", roles)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch token := r.URL.Query().Get("pageToken"); token {
+		case "":
+			fmt.Fprint(w, `{"members":[{"id":"1","email":"a@domain.ext"},{"id":"2","email":"b@domain.ext"}],"nextPageToken":"page-2"}`)
+		case "page-2":
+			fmt.Fprint(w, `{"members":[{"id":"3","email":"c@domain.ext"}],"nextPageToken":"page-3"}`)
+		case "page-3":
+			fmt.Fprint(w, `{"members":[{"id":"4","email":"d@domain.ext"}]}`)
+		default:
+			t.Errorf("unexpected page token: %q", token)
+		}
+	})
+
+	members, err := listAPIMembers("group@domain.ext", "OWNER,MANAGER", config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	emails := []string{}
+	for _, member := range members {
+		emails = append(emails, member.Email)
+	}
+	if expected := "a@domain.ext,b@domain.ext,c@domain.ext,d@domain.ext"; strings.Join(emails, ",") != expected {
+		t.Errorf("expected the members of all pages %s, got %v", expected, emails)
+	}
+}
+
+func TestUpsertMember_nestedGroup(t *testing.T) {
+	config, inserts := testNestedGroupServer(t)
+
+	if err := upsertMember("child@domain.ext", "parent@domain.ext", "OWNER", "ALL_MAIL", config); err == nil || !strings.Contains(err.Error(), "MEMBER role") {
+		t.Fatalf("expected an error about the role of the nested group, got %v", err)
+	}
+
+	if err := upsertMember("child@domain.ext", "parent@domain.ext", "member", "ALL_MAIL", config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(*inserts) != 1 || !strings.Contains((*inserts)[0], `"type":"GROUP"`) {
+		t.Fatalf("expected the group to be inserted as member of type GROUP, got %v", *inserts)
+	}
+	if strings.Contains((*inserts)[0], "deliverySettings") {
+		t.Errorf("expected no delivery settings for the nested group, got %s", (*inserts)[0])
+	}
+}
+
+func TestCreateOrUpdateGroupMembers_requireOwner(t *testing.T) {
+	changes := []string{}
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			changes = append(changes, r.Method+" "+r.URL.Path)
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprint(w, `{"members":[{"id":"1","email":"owner@domain.ext","role":"OWNER"},{"id":"2","email":"bot@domain.ext","role":"OWNER"}]}`)
+	})
+
+	// Demoting the last managed owner leaves the group without owners
+	d := schema.TestResourceDataRaw(t, resourceGroupMembers().Schema, map[string]interface{}{
+		"group_email": "group@domain.ext",
+		"member": []interface{}{
+			map[string]interface{}{"email": "owner@domain.ext", "role": "MEMBER"},
+		},
+		"require_owner": true,
+	})
+	if _, err := createOrUpdateGroupMembers(d, config); err == nil || !strings.Contains(err.Error(), "without an OWNER") {
+		t.Fatalf("expected an error about the group being left without owners, got %v", err)
+	}
+	if len(changes) > 0 {
+		t.Fatalf("expected no members to be changed, got %v", changes)
+	}
+
+	// The unmanaged owner is left alone, so the group keeps an owner
+	d = schema.TestResourceDataRaw(t, resourceGroupMembers().Schema, map[string]interface{}{
+		"group_email": "group@domain.ext",
+		"member": []interface{}{
+			map[string]interface{}{"email": "owner@domain.ext", "role": "MEMBER"},
+		},
+		"require_owner":            true,
+		"ignore_unmanaged_members": true,
+	})
+	if _, err := createOrUpdateGroupMembers(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestDeleteMembers_batch(t *testing.T) {
+	var deletes []string
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		deletes = append(deletes, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"code":403,"message":"Not Authorized to access this resource/api"}}`)
+	})
+	server, calls, batches := testBatchServer(func(method, path string) int {
+		if strings.HasSuffix(path, "/denied@domain.ext") {
+			return http.StatusForbidden
+		}
+		return http.StatusNoContent
+	})
+	defer server.Close()
+	config.batchURL = server.URL
+
+	err := deleteMembers("group@domain.ext", []string{"a@domain.ext", "b@domain.ext", "denied@domain.ext"}, config)
+	if err == nil || !strings.Contains(err.Error(), "denied@domain.ext") {
+		t.Fatalf("expected an error for the denied member, got %v", err)
+	}
+	if *batches != 1 || len(*calls) != 3 {
+		t.Errorf("expected the members to be removed in 1 batch request, got %d with calls %v", *batches, *calls)
+	}
+	if len(deletes) != 1 || !strings.HasSuffix(deletes[0], "/members/denied@domain.ext") {
+		t.Errorf("expected only the denied member to be removed on its own, got %v", deletes)
+	}
+}
+
+func TestDesiredOwners(t *testing.T) {
+	cfgMembers := []map[string]interface{}{
+		{"email": "Owner@domain.ext", "role": "owner"},
+		{"email": "bot@domain.ext", "role": "MEMBER"},
+	}
+	unmanaged := []*directory.Member{
+		{Email: "bot@domain.ext", Role: "OWNER"},
+		{Email: "admin@domain.ext", Role: "OWNER"},
+		{Email: "user@domain.ext", Role: "MEMBER"},
+	}
+
+	// The configured role of bot@ wins over its role in the API
+	if owners := strings.Join(desiredOwners(cfgMembers, unmanaged), ","); owners != "owner@domain.ext,admin@domain.ext" {
+		t.Errorf("expected the owners owner@domain.ext,admin@domain.ext, got %s", owners)
+	}
+}
+
+func TestGroupMembersHash_status(t *testing.T) {
+	hash := schema.HashResource(resourceGroupMembers().Schema["member"].Elem.(*schema.Resource))
+
+	configured := map[string]interface{}{
+		"email":             "user@domain.ext",
+		"role":              "MEMBER",
+		"delivery_settings": "ALL_MAIL",
+	}
+	read := map[string]interface{}{
+		"email":             "user@domain.ext",
+		"role":              "MEMBER",
+		"delivery_settings": "ALL_MAIL",
+		"status":            "SUSPENDED",
+		"type":              "USER",
+	}
+
+	// A suspended member must not show up as a changed member
+	if hash(configured) != hash(read) {
+		t.Fatalf("expected the computed status not to change the hash of a member")
+	}
+}
+
+func TestMergeMemberDeliverySettings(t *testing.T) {
+	members := []map[string]interface{}{
+		{"email": "digest@domain.ext", "delivery_settings": "DIGEST"},
+		{"email": "listed@domain.ext", "delivery_settings": ""},
+		{"email": "new@domain.ext", "delivery_settings": ""},
+	}
+	stateMembers := []interface{}{
+		map[string]interface{}{"email": "Digest@domain.ext", "delivery_settings": "ALL_MAIL"},
+		map[string]interface{}{"email": "listed@domain.ext", "delivery_settings": "NONE"},
+	}
+
+	// The API value wins over state, state only fills in missing values
+	expected := map[string]string{
+		"digest@domain.ext": "DIGEST",
+		"listed@domain.ext": "NONE",
+		"new@domain.ext":    "ALL_MAIL",
+	}
+	for _, member := range mergeMemberDeliverySettings(members, stateMembers) {
+		email := member["email"].(string)
+		if member["delivery_settings"] != expected[email] {
+			t.Errorf("%s: expected delivery settings %s, got %v", email, expected[email], member["delivery_settings"])
+		}
+	}
+}
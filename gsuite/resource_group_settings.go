@@ -3,6 +3,9 @@ package gsuite
 import (
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -11,8 +14,99 @@ import (
 	groupSettings "google.golang.org/api/groupssettings/v1"
 )
 
+// The group settings API represents booleans as the strings "true" and
+// "false", they are stored as bools in state and converted on every call.
+func formatStringBool(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+func parseStringBool(s string) bool {
+	return strings.EqualFold(s, "true")
+}
+
+// groupSettingsFields maps the managed settings to their field in the API,
+// these are the settings which can be listed in ignore_fields.
+var groupSettingsFields = map[string]string{
+	"allow_external_members":         "AllowExternalMembers",
+	"allow_web_posting":              "AllowWebPosting",
+	"archive_only":                   "ArchiveOnly",
+	"custom_footer_text":             "CustomFooterText",
+	"custom_reply_to":                "CustomReplyTo",
+	"enable_collaborative_inbox":     "EnableCollaborativeInbox",
+	"favorite_replies_on_top":        "FavoriteRepliesOnTop",
+	"include_custom_footer":          "IncludeCustomFooter",
+	"include_in_global_address_list": "IncludeInGlobalAddressList",
+	"members_can_post_as_the_group":  "MembersCanPostAsTheGroup",
+	"message_moderation_level":       "MessageModerationLevel",
+	"primary_language":               "PrimaryLanguage",
+	"reply_to":                       "ReplyTo",
+	"send_message_deny_notification": "SendMessageDenyNotification",
+	"spam_moderation_level":          "SpamModerationLevel",
+	"who_can_approve_members":        "WhoCanApproveMembers",
+	"who_can_assist_content":         "WhoCanAssistContent",
+	"who_can_contact_owner":          "WhoCanContactOwner",
+	"who_can_discover_group":         "WhoCanDiscoverGroup",
+	"who_can_join":                   "WhoCanJoin",
+	"who_can_leave_group":            "WhoCanLeaveGroup",
+	"who_can_moderate_content":       "WhoCanModerateContent",
+	"who_can_moderate_members":       "WhoCanModerateMembers",
+	"who_can_post_message":           "WhoCanPostMessage",
+	"who_can_view_group":             "WhoCanViewGroup",
+	"who_can_view_membership":        "WhoCanViewMembership",
+}
+
+func groupSettingsFieldNames() []string {
+	names := make([]string, 0, len(groupSettingsFields))
+	for name := range groupSettingsFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// groupSettingsIgnoredDiffSuppress hides the diff of settings listed in
+// ignore_fields, they are managed outside of Terraform. Their value is still
+// read into state.
+func groupSettingsIgnoredDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("ignore_fields").(*schema.Set).Contains(k)
+}
+
+// clearIgnoredGroupSettings removes the settings listed in ignore_fields from
+// the request, so their value on the API side is left alone.
+func clearIgnoredGroupSettings(d *schema.ResourceData, groupSetting *groupSettings.Groups) {
+	v := reflect.ValueOf(groupSetting).Elem()
+	for _, k := range convertStringSet(d.Get("ignore_fields").(*schema.Set)) {
+		field := groupSettingsFields[k]
+		log.Printf("[DEBUG] Ignoring group setting %s", k)
+		v.FieldByName(field).SetString("")
+		groupSetting.NullFields = stringSliceDifference(groupSetting.NullFields, []string{field})
+	}
+}
+
+// validateGroupSettingsReplyTo checks that replies sent to the custom reply-to
+// address have an address to go to.
+func validateGroupSettingsReplyTo(replyTo, customReplyTo string) error {
+	if replyTo == "REPLY_TO_CUSTOM" && customReplyTo == "" {
+		return fmt.Errorf("custom_reply_to must be set when reply_to is REPLY_TO_CUSTOM")
+	}
+	return nil
+}
+
+func resourceGroupSettingsCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("reply_to") || !d.NewValueKnown("custom_reply_to") || !d.NewValueKnown("ignore_fields") {
+		return nil
+	}
+
+	// Ignored settings are not sent, the API side may well be consistent
+	ignored := d.Get("ignore_fields").(*schema.Set)
+	if ignored.Contains("reply_to") || ignored.Contains("custom_reply_to") {
+		return nil
+	}
+	return validateGroupSettingsReplyTo(d.Get("reply_to").(string), d.Get("custom_reply_to").(string))
+}
+
 func resourceGroupSettings() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceGroupSettingsCreate,
 		Read:   resourceGroupSettingsRead,
 		Update: resourceGroupSettingsUpdate,
@@ -21,9 +115,29 @@ func resourceGroupSettings() *schema.Resource {
 			State: resourceGroupSettingsImporter,
 		},
 
+		CustomizeDiff: resourceGroupSettingsCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
+			// Settings which are managed outside of Terraform, e.g. by
+			// org-level defaults, are neither sent nor compared
+			"ignore_fields": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(groupSettingsFieldNames(), false),
+				},
+			},
+
+			// Manage the settings as another admin than the provider's
+			"impersonated_user_email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEmail,
+			},
+
 			"is_archived": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Computed: true,
 			},
 			"kind": {
@@ -44,9 +158,9 @@ func resourceGroupSettings() *schema.Resource {
 				ValidateFunc: validateEmail,
 			},
 			"allow_external_members": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Default:  false,
 			},
 			"allow_google_communication": {
 				Type:     schema.TypeString,
@@ -54,37 +168,48 @@ func resourceGroupSettings() *schema.Resource {
 				Removed:  "Removed.",
 			},
 			"allow_web_posting": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "true",
+				Default:  true,
 			},
 			"archive_only": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Default:  false,
 			},
 			"custom_footer_text": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			// Required when reply_to is REPLY_TO_CUSTOM
 			"custom_reply_to": {
-				Type:     schema.TypeString,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEmail,
+			},
+			"custom_roles_enabled_for_settings_to_be_merged": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enable_collaborative_inbox": {
+				Type:     schema.TypeBool,
 				Optional: true,
+				Default:  false,
 			},
 			"favorite_replies_on_top": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "true",
+				Default:  true,
 			},
 			"include_custom_footer": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Default:  false,
 			},
 			"include_in_global_address_list": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "true",
+				Default:  true,
 			},
 			"max_message_bytes": {
 				Type:     schema.TypeInt,
@@ -92,9 +217,9 @@ func resourceGroupSettings() *schema.Resource {
 				Removed:  "Removed.",
 			},
 			"members_can_post_as_the_group": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Default:  false,
 			},
 			"message_display_font": {
 				Type:     schema.TypeString,
@@ -118,9 +243,9 @@ func resourceGroupSettings() *schema.Resource {
 				Default:      "REPLY_TO_IGNORE",
 			},
 			"send_message_deny_notification": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Default:  false,
 			},
 			"show_in_group_directory": {
 				Type:     schema.TypeString,
@@ -321,27 +446,30 @@ func resourceGroupSettings() *schema.Resource {
 			},
 		},
 	}
+
+	for name := range groupSettingsFields {
+		r.Schema[name].DiffSuppressFunc = groupSettingsIgnoredDiffSuppress
+	}
+
+	return r
 }
 
 func resourceGroupSettingsCreate(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
+	config, err := impersonatedConfig(d, meta)
+	if err != nil {
+		return err
+	}
 
 	// GroupSettings
 	groupSetting := &groupSettings.Groups{
 		Email: strings.ToLower(d.Get("email").(string)),
 	}
-	if v, ok := d.GetOk("allow_external_members"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "allow_external_members", v.(string))
-		groupSetting.AllowExternalMembers = v.(string)
-	}
-	if v, ok := d.GetOk("allow_web_posting"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "allow_web_posting", v.(string))
-		groupSetting.AllowWebPosting = v.(string)
-	}
-	if v, ok := d.GetOk("archive_only"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "archive_only", v.(string))
-		groupSetting.ArchiveOnly = v.(string)
-	}
+	log.Printf("[DEBUG] Setting %s: %t", "allow_external_members", d.Get("allow_external_members").(bool))
+	groupSetting.AllowExternalMembers = formatStringBool(d.Get("allow_external_members").(bool))
+	log.Printf("[DEBUG] Setting %s: %t", "allow_web_posting", d.Get("allow_web_posting").(bool))
+	groupSetting.AllowWebPosting = formatStringBool(d.Get("allow_web_posting").(bool))
+	log.Printf("[DEBUG] Setting %s: %t", "archive_only", d.Get("archive_only").(bool))
+	groupSetting.ArchiveOnly = formatStringBool(d.Get("archive_only").(bool))
 	if v, ok := d.GetOk("custom_footer_text"); ok {
 		log.Printf("[DEBUG] Setting %s: %s", "custom_footer_text", v.(string))
 		groupSetting.CustomFooterText = v.(string)
@@ -354,22 +482,16 @@ func resourceGroupSettingsCreate(d *schema.ResourceData, meta interface{}) error
 		log.Printf("[DEBUG] Setting %s: %s", "description", v.(string))
 		groupSetting.Description = v.(string)
 	}
-	if v, ok := d.GetOk("favorite_replies_on_top"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "favorite_replies_on_top", v.(string))
-		groupSetting.FavoriteRepliesOnTop = v.(string)
-	}
-	if v, ok := d.GetOk("include_custom_footer"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "include_custom_footer", v.(string))
-		groupSetting.IncludeCustomFooter = v.(string)
-	}
-	if v, ok := d.GetOk("include_in_global_address_list"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "include_in_global_address_list", v.(string))
-		groupSetting.IncludeInGlobalAddressList = v.(string)
-	}
-	if v, ok := d.GetOk("members_can_post_as_the_group"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "members_can_post_as_the_group", v.(string))
-		groupSetting.MembersCanPostAsTheGroup = v.(string)
-	}
+	log.Printf("[DEBUG] Setting %s: %t", "enable_collaborative_inbox", d.Get("enable_collaborative_inbox").(bool))
+	groupSetting.EnableCollaborativeInbox = formatStringBool(d.Get("enable_collaborative_inbox").(bool))
+	log.Printf("[DEBUG] Setting %s: %t", "favorite_replies_on_top", d.Get("favorite_replies_on_top").(bool))
+	groupSetting.FavoriteRepliesOnTop = formatStringBool(d.Get("favorite_replies_on_top").(bool))
+	log.Printf("[DEBUG] Setting %s: %t", "include_custom_footer", d.Get("include_custom_footer").(bool))
+	groupSetting.IncludeCustomFooter = formatStringBool(d.Get("include_custom_footer").(bool))
+	log.Printf("[DEBUG] Setting %s: %t", "include_in_global_address_list", d.Get("include_in_global_address_list").(bool))
+	groupSetting.IncludeInGlobalAddressList = formatStringBool(d.Get("include_in_global_address_list").(bool))
+	log.Printf("[DEBUG] Setting %s: %t", "members_can_post_as_the_group", d.Get("members_can_post_as_the_group").(bool))
+	groupSetting.MembersCanPostAsTheGroup = formatStringBool(d.Get("members_can_post_as_the_group").(bool))
 	if v, ok := d.GetOk("message_moderation_level"); ok {
 		log.Printf("[DEBUG] Setting %s: %s", "message_moderation_level", v.(string))
 		groupSetting.MessageModerationLevel = v.(string)
@@ -382,10 +504,8 @@ func resourceGroupSettingsCreate(d *schema.ResourceData, meta interface{}) error
 		log.Printf("[DEBUG] Setting %s: %s", "reply_to", v.(string))
 		groupSetting.ReplyTo = v.(string)
 	}
-	if v, ok := d.GetOk("send_message_deny_notification"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "send_message_deny_notification", v.(string))
-		groupSetting.SendMessageDenyNotification = v.(string)
-	}
+	log.Printf("[DEBUG] Setting %s: %t", "send_message_deny_notification", d.Get("send_message_deny_notification").(bool))
+	groupSetting.SendMessageDenyNotification = formatStringBool(d.Get("send_message_deny_notification").(bool))
 	if v, ok := d.GetOk("spam_moderation_level"); ok {
 		log.Printf("[DEBUG] Setting %s: %s", "spam_moderation_level", v.(string))
 		groupSetting.SpamModerationLevel = v.(string)
@@ -435,7 +555,8 @@ func resourceGroupSettingsCreate(d *schema.ResourceData, meta interface{}) error
 		groupSetting.WhoCanViewMembership = v.(string)
 	}
 
-	var err error
+	clearIgnoredGroupSettings(d, groupSetting)
+
 	err = retry(func() error {
 		_, err = config.groupSettings.Groups.Update(d.Get("email").(string), groupSetting).Do()
 		return err
@@ -448,7 +569,10 @@ func resourceGroupSettingsCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceGroupSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
+	config, err := impersonatedConfig(d, meta)
+	if err != nil {
+		return err
+	}
 
 	// GroupSettings
 	nullFields := []string{}
@@ -456,34 +580,16 @@ func resourceGroupSettingsUpdate(d *schema.ResourceData, meta interface{}) error
 		Email: strings.ToLower(d.Get("email").(string)),
 	}
 	if d.HasChange("allow_external_members") {
-		if v, ok := d.GetOk("allow_external_members"); ok {
-			log.Printf("[DEBUG] Updating group allow external members: %s", v.(string))
-			groupSetting.AllowExternalMembers = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting AllowExternalMembers")
-			groupSetting.AllowExternalMembers = ""
-			nullFields = append(nullFields, "AllowExternalMembers")
-		}
+		log.Printf("[DEBUG] Updating allow_external_members: %t", d.Get("allow_external_members").(bool))
+		groupSetting.AllowExternalMembers = formatStringBool(d.Get("allow_external_members").(bool))
 	}
 	if d.HasChange("allow_web_posting") {
-		if v, ok := d.GetOk("allow_web_posting"); ok {
-			log.Printf("[DEBUG] Updating allow_web_posting: %s", v.(string))
-			groupSetting.AllowWebPosting = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting AllowWebPosting")
-			groupSetting.AllowWebPosting = ""
-			nullFields = append(nullFields, "AllowWebPosting")
-		}
+		log.Printf("[DEBUG] Updating allow_web_posting: %t", d.Get("allow_web_posting").(bool))
+		groupSetting.AllowWebPosting = formatStringBool(d.Get("allow_web_posting").(bool))
 	}
 	if d.HasChange("archive_only") {
-		if v, ok := d.GetOk("archive_only"); ok {
-			log.Printf("[DEBUG] Updating archive_only: %s", v.(string))
-			groupSetting.ArchiveOnly = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting ArchiveOnly")
-			groupSetting.ArchiveOnly = ""
-			nullFields = append(nullFields, "ArchiveOnly")
-		}
+		log.Printf("[DEBUG] Updating archive_only: %t", d.Get("archive_only").(bool))
+		groupSetting.ArchiveOnly = formatStringBool(d.Get("archive_only").(bool))
 	}
 	if d.HasChange("custom_footer_text") {
 		if v, ok := d.GetOk("custom_footer_text"); ok {
@@ -515,45 +621,25 @@ func resourceGroupSettingsUpdate(d *schema.ResourceData, meta interface{}) error
 			nullFields = append(nullFields, "Description")
 		}
 	}
+	if d.HasChange("enable_collaborative_inbox") {
+		log.Printf("[DEBUG] Updating enable_collaborative_inbox: %t", d.Get("enable_collaborative_inbox").(bool))
+		groupSetting.EnableCollaborativeInbox = formatStringBool(d.Get("enable_collaborative_inbox").(bool))
+	}
 	if d.HasChange("favorite_replies_on_top") {
-		if v, ok := d.GetOk("favorite_replies_on_top"); ok {
-			log.Printf("[DEBUG] Updating favorite_replies_on_top: %s", v.(string))
-			groupSetting.FavoriteRepliesOnTop = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting FavoriteRepliesOnTop")
-			groupSetting.FavoriteRepliesOnTop = ""
-			nullFields = append(nullFields, "FavoriteRepliesOnTop")
-		}
+		log.Printf("[DEBUG] Updating favorite_replies_on_top: %t", d.Get("favorite_replies_on_top").(bool))
+		groupSetting.FavoriteRepliesOnTop = formatStringBool(d.Get("favorite_replies_on_top").(bool))
 	}
 	if d.HasChange("include_custom_footer") {
-		if v, ok := d.GetOk("include_custom_footer"); ok {
-			log.Printf("[DEBUG] Updating include_custom_footer: %s", v.(string))
-			groupSetting.IncludeCustomFooter = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting IncludeCustomFooter")
-			groupSetting.IncludeCustomFooter = ""
-			nullFields = append(nullFields, "IncludeCustomFooter")
-		}
+		log.Printf("[DEBUG] Updating include_custom_footer: %t", d.Get("include_custom_footer").(bool))
+		groupSetting.IncludeCustomFooter = formatStringBool(d.Get("include_custom_footer").(bool))
 	}
 	if d.HasChange("include_in_global_address_list") {
-		if v, ok := d.GetOk("include_in_global_address_list"); ok {
-			log.Printf("[DEBUG] Updating include_in_global_address_list: %s", v.(string))
-			groupSetting.IncludeInGlobalAddressList = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting IncludeInGlobalAddressList")
-			groupSetting.IncludeInGlobalAddressList = ""
-			nullFields = append(nullFields, "IncludeInGlobalAddressList")
-		}
+		log.Printf("[DEBUG] Updating include_in_global_address_list: %t", d.Get("include_in_global_address_list").(bool))
+		groupSetting.IncludeInGlobalAddressList = formatStringBool(d.Get("include_in_global_address_list").(bool))
 	}
 	if d.HasChange("members_can_post_as_the_group") {
-		if v, ok := d.GetOk("members_can_post_as_the_group"); ok {
-			log.Printf("[DEBUG] Updating members_can_post_as_the_group: %s", v.(string))
-			groupSetting.MembersCanPostAsTheGroup = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting MembersCanPostAsTheGroup")
-			groupSetting.MembersCanPostAsTheGroup = ""
-			nullFields = append(nullFields, "MembersCanPostAsTheGroup")
-		}
+		log.Printf("[DEBUG] Updating members_can_post_as_the_group: %t", d.Get("members_can_post_as_the_group").(bool))
+		groupSetting.MembersCanPostAsTheGroup = formatStringBool(d.Get("members_can_post_as_the_group").(bool))
 	}
 	if d.HasChange("message_moderation_level") {
 		if v, ok := d.GetOk("message_moderation_level"); ok {
@@ -565,9 +651,9 @@ func resourceGroupSettingsUpdate(d *schema.ResourceData, meta interface{}) error
 			nullFields = append(nullFields, "MessageModerationLevel")
 		}
 	}
-	if d.HasChange("PrimaryLanguage") {
-		if v, ok := d.GetOk("PrimaryLanguage"); ok {
-			log.Printf("[DEBUG] Updating PrimaryLanguage: %s", v.(string))
+	if d.HasChange("primary_language") {
+		if v, ok := d.GetOk("primary_language"); ok {
+			log.Printf("[DEBUG] Updating primary_language: %s", v.(string))
 			groupSetting.PrimaryLanguage = v.(string)
 		} else {
 			log.Printf("[DEBUG] Removing groupSetting PrimaryLanguage")
@@ -586,14 +672,8 @@ func resourceGroupSettingsUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 	if d.HasChange("send_message_deny_notification") {
-		if v, ok := d.GetOk("send_message_deny_notification"); ok {
-			log.Printf("[DEBUG] Updating send_message_deny_notification: %s", v.(string))
-			groupSetting.SendMessageDenyNotification = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting SendMessageDenyNotification")
-			groupSetting.SendMessageDenyNotification = ""
-			nullFields = append(nullFields, "SendMessageDenyNotification")
-		}
+		log.Printf("[DEBUG] Updating send_message_deny_notification: %t", d.Get("send_message_deny_notification").(bool))
+		groupSetting.SendMessageDenyNotification = formatStringBool(d.Get("send_message_deny_notification").(bool))
 	}
 	if d.HasChange("spam_moderation_level") {
 		if v, ok := d.GetOk("spam_moderation_level"); ok {
@@ -716,7 +796,8 @@ func resourceGroupSettingsUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	var err error
+	clearIgnoredGroupSettings(d, groupSetting)
+
 	err = retry(func() error {
 		_, err = config.groupSettings.Groups.Update(d.Get("email").(string), groupSetting).Do()
 		return err
@@ -730,9 +811,11 @@ func resourceGroupSettingsUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceGroupSettingsRead(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
+	config, err := impersonatedConfig(d, meta)
+	if err != nil {
+		return err
+	}
 
-	var err error
 	var groupSetting *groupSettings.Groups
 	err = retryInvalid(func() error {
 		groupSetting, err = config.groupSettings.Groups.Get(d.Get("email").(string)).Do()
@@ -744,20 +827,23 @@ func resourceGroupSettingsRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.SetId(d.Get("email").(string))
-	d.Set("allow_external_members", groupSetting.AllowExternalMembers)
-	d.Set("allow_web_posting", groupSetting.AllowWebPosting)
-	d.Set("archive_only", groupSetting.ArchiveOnly)
+	d.Set("allow_external_members", parseStringBool(groupSetting.AllowExternalMembers))
+	d.Set("allow_web_posting", parseStringBool(groupSetting.AllowWebPosting))
+	d.Set("archive_only", parseStringBool(groupSetting.ArchiveOnly))
 	d.Set("custom_footer_text", groupSetting.CustomFooterText)
 	d.Set("custom_reply_to", groupSetting.CustomReplyTo)
+	d.Set("custom_roles_enabled_for_settings_to_be_merged", parseStringBool(groupSetting.CustomRolesEnabledForSettingsToBeMerged))
+	d.Set("is_archived", parseStringBool(groupSetting.IsArchived))
 	d.Set("description", groupSetting.Description)
-	d.Set("favorite_replies_on_top", groupSetting.FavoriteRepliesOnTop)
-	d.Set("include_custom_footer", groupSetting.IncludeCustomFooter)
-	d.Set("include_in_global_address_list", groupSetting.IncludeInGlobalAddressList)
-	d.Set("members_can_post_as_the_group", groupSetting.MembersCanPostAsTheGroup)
+	d.Set("enable_collaborative_inbox", parseStringBool(groupSetting.EnableCollaborativeInbox))
+	d.Set("favorite_replies_on_top", parseStringBool(groupSetting.FavoriteRepliesOnTop))
+	d.Set("include_custom_footer", parseStringBool(groupSetting.IncludeCustomFooter))
+	d.Set("include_in_global_address_list", parseStringBool(groupSetting.IncludeInGlobalAddressList))
+	d.Set("members_can_post_as_the_group", parseStringBool(groupSetting.MembersCanPostAsTheGroup))
 	d.Set("message_moderation_level", groupSetting.MessageModerationLevel)
 	d.Set("primary_language", groupSetting.PrimaryLanguage)
 	d.Set("reply_to", groupSetting.ReplyTo)
-	d.Set("send_message_deny_notification", groupSetting.SendMessageDenyNotification)
+	d.Set("send_message_deny_notification", parseStringBool(groupSetting.SendMessageDenyNotification))
 	d.Set("spam_moderation_level", groupSetting.SpamModerationLevel)
 	d.Set("who_can_approve_members", groupSetting.WhoCanApproveMembers)
 	d.Set("who_can_assist_content", groupSetting.WhoCanAssistContent)
@@ -779,43 +865,19 @@ func resourceGroupSettingsDelete(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-// Allow importing using email
+// Allow importing using the group email, the settings are read like on every
+// refresh so that the string booleans are decoded the same way
 func resourceGroupSettingsImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
+	email := strings.ToLower(d.Id())
+	d.SetId(email)
+	d.Set("email", email)
 
-	id, err := config.groupSettings.Groups.Get(d.Id()).Do()
-	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error fetching group settings. Make sure the group '%s' exists: %s ", d.Id(), err)
-	}
-
-	d.SetId(d.Id())
-	d.Set("allow_external_members", id.AllowExternalMembers)
-	d.Set("allow_web_posting", id.AllowWebPosting)
-	d.Set("archive_only", id.ArchiveOnly)
-	d.Set("custom_footer_text", id.CustomFooterText)
-	d.Set("custom_reply_to", id.CustomReplyTo)
-	d.Set("description", id.Description)
-	d.Set("email", id.Email)
-	d.Set("favorite_replies_on_top", id.FavoriteRepliesOnTop)
-	d.Set("include_custom_footer", id.IncludeCustomFooter)
-	d.Set("include_in_global_address_list", id.IncludeInGlobalAddressList)
-	d.Set("members_can_post_as_the_group", id.MembersCanPostAsTheGroup)
-	d.Set("message_moderation_level", id.MessageModerationLevel)
-	d.Set("primary_language", id.PrimaryLanguage)
-	d.Set("reply_to", id.ReplyTo)
-	d.Set("send_message_deny_notification", id.SendMessageDenyNotification)
-	d.Set("spam_moderation_level", id.SpamModerationLevel)
-	d.Set("who_can_approve_members", id.WhoCanApproveMembers)
-	d.Set("who_can_assist_content", id.WhoCanAssistContent)
-	d.Set("who_can_contact_owner", id.WhoCanContactOwner)
-	d.Set("who_can_discover_group", id.WhoCanDiscoverGroup)
-	d.Set("who_can_join", id.WhoCanJoin)
-	d.Set("who_can_leave_group", id.WhoCanLeaveGroup)
-	d.Set("who_can_moderate_content", id.WhoCanModerateContent)
-	d.Set("who_can_moderate_members", id.WhoCanModerateMembers)
-	d.Set("who_can_post_message", id.WhoCanPostMessage)
-	d.Set("who_can_view_group", id.WhoCanViewGroup)
-	d.Set("who_can_view_membership", id.WhoCanViewMembership)
+	if err := resourceGroupSettingsRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("[ERROR] Error fetching group settings. Make sure the group '%s' exists", email)
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
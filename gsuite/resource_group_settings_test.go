@@ -0,0 +1,386 @@
+package gsuite
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	groupSettings "google.golang.org/api/groupssettings/v1"
+)
+
+// testGroupSettingsServer fakes a group settings API which stores the settings
+// it receives and returns them on the following requests.
+func testGroupSettingsServer(t *testing.T) (*Config, *groupSettings.Groups) {
+	stored := &groupSettings.Groups{}
+
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			body, _ := ioutil.ReadAll(r.Body)
+			*stored = groupSettings.Groups{}
+			if err := json.Unmarshal(body, stored); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			stored.CustomRolesEnabledForSettingsToBeMerged = "true"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(stored)
+	})
+
+	return config, stored
+}
+
+func TestParseStringBool(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected bool
+	}{
+		{"true", true},
+		{"True", true},
+		{"false", false},
+		{"", false},
+	}
+
+	for _, testCase := range testCases {
+		if b := parseStringBool(testCase.value); b != testCase.expected {
+			t.Errorf("expected %q to be parsed as %t, got %t", testCase.value, testCase.expected, b)
+		}
+	}
+}
+
+func TestResourceGroupSettings_stringBoolRoundTrip(t *testing.T) {
+	fields := map[string]func(*groupSettings.Groups) string{
+		"allow_external_members":         func(g *groupSettings.Groups) string { return g.AllowExternalMembers },
+		"allow_web_posting":              func(g *groupSettings.Groups) string { return g.AllowWebPosting },
+		"archive_only":                   func(g *groupSettings.Groups) string { return g.ArchiveOnly },
+		"enable_collaborative_inbox":     func(g *groupSettings.Groups) string { return g.EnableCollaborativeInbox },
+		"favorite_replies_on_top":        func(g *groupSettings.Groups) string { return g.FavoriteRepliesOnTop },
+		"include_custom_footer":          func(g *groupSettings.Groups) string { return g.IncludeCustomFooter },
+		"include_in_global_address_list": func(g *groupSettings.Groups) string { return g.IncludeInGlobalAddressList },
+		"members_can_post_as_the_group":  func(g *groupSettings.Groups) string { return g.MembersCanPostAsTheGroup },
+		"send_message_deny_notification": func(g *groupSettings.Groups) string { return g.SendMessageDenyNotification },
+	}
+
+	for field, get := range fields {
+		for _, value := range []bool{true, false} {
+			config, stored := testGroupSettingsServer(t)
+
+			d := schema.TestResourceDataRaw(t, resourceGroupSettings().Schema, map[string]interface{}{
+				"email": "group@domain.ext",
+				field:   value,
+			})
+			err := resourceGroupSettingsCreate(d, config)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if sent := get(stored); sent != formatStringBool(value) {
+				t.Errorf("expected %s to be sent as %q, got %q", field, formatStringBool(value), sent)
+			}
+			if actual := d.Get(field).(bool); actual != value {
+				t.Errorf("expected %s to be read back as %t, got %t", field, value, actual)
+			}
+		}
+	}
+}
+
+func TestResourceGroupSettingsCreate_moderationFields(t *testing.T) {
+	config, stored := testGroupSettingsServer(t)
+
+	expected := map[string]string{
+		"who_can_assist_content":   "MANAGERS_ONLY",
+		"who_can_discover_group":   "ALL_IN_DOMAIN_CAN_DISCOVER",
+		"who_can_moderate_content": "OWNERS_ONLY",
+		"who_can_moderate_members": "ALL_MEMBERS",
+	}
+	raw := map[string]interface{}{
+		"email":                      "group@domain.ext",
+		"enable_collaborative_inbox": true,
+	}
+	for k, v := range expected {
+		raw[k] = v
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceGroupSettings().Schema, raw)
+	if err := resourceGroupSettingsCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if stored.EnableCollaborativeInbox != "true" || stored.WhoCanModerateMembers != "ALL_MEMBERS" {
+		t.Errorf("expected the moderation fields to be sent, got %+v", stored)
+	}
+
+	for k, v := range expected {
+		if actual := d.Get(k).(string); actual != v {
+			t.Errorf("expected %s to be read back as %q, got %q", k, v, actual)
+		}
+	}
+	if !d.Get("enable_collaborative_inbox").(bool) || !d.Get("custom_roles_enabled_for_settings_to_be_merged").(bool) {
+		t.Errorf("expected the collaborative inbox and custom roles to be read back as enabled")
+	}
+}
+
+func TestResourceGroupSettings_ignoreFields(t *testing.T) {
+	config, stored := testGroupSettingsServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceGroupSettings().Schema, map[string]interface{}{
+		"email":              "group@domain.ext",
+		"ignore_fields":      []interface{}{"who_can_join", "allow_web_posting"},
+		"who_can_join":       "ANYONE_CAN_JOIN",
+		"who_can_view_group": "ALL_IN_DOMAIN_CAN_VIEW",
+	})
+	if err := resourceGroupSettingsCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if stored.WhoCanJoin != "" || stored.AllowWebPosting != "" {
+		t.Errorf("expected the ignored settings not to be sent, got %q and %q", stored.WhoCanJoin, stored.AllowWebPosting)
+	}
+	if stored.WhoCanViewGroup != "ALL_IN_DOMAIN_CAN_VIEW" {
+		t.Errorf("expected the other settings to be sent, got %q", stored.WhoCanViewGroup)
+	}
+
+	// The ignored setting is changed by another tool
+	state := &terraform.InstanceState{
+		ID: "group@domain.ext",
+		Attributes: map[string]string{
+			"id":              "group@domain.ext",
+			"email":           "group@domain.ext",
+			"ignore_fields.#": "1",
+			fmt.Sprintf("ignore_fields.%d", schema.HashString("who_can_join")): "who_can_join",
+			"who_can_join":       "INVITED_CAN_JOIN",
+			"who_can_view_group": "ALL_MEMBERS_CAN_VIEW",
+		},
+	}
+	diff, err := resourceGroupSettings().Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email":         "group@domain.ext",
+		"ignore_fields": []interface{}{"who_can_join"},
+		"who_can_join":  "ANYONE_CAN_JOIN",
+	}), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := diff.Attributes["who_can_join"]; ok {
+		t.Errorf("expected no diff for the ignored setting, got %#v", diff.Attributes["who_can_join"])
+	}
+	if _, ok := diff.Attributes["who_can_view_group"]; ok {
+		t.Errorf("expected no diff for an unchanged setting, got %#v", diff.Attributes["who_can_view_group"])
+	}
+	if _, ok := diff.Attributes["who_can_post_message"]; !ok {
+		t.Errorf("expected a diff for a setting which is not ignored")
+	}
+}
+
+func TestResourceGroupSettingsUpdate_falseBooleans(t *testing.T) {
+	meta, stored := testGroupSettingsServer(t)
+
+	state := &terraform.InstanceState{
+		ID: "group@domain.ext",
+		Attributes: map[string]string{
+			"id":                     "group@domain.ext",
+			"email":                  "group@domain.ext",
+			"allow_external_members": "true",
+			"archive_only":           "true",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email":                  "group@domain.ext",
+		"allow_external_members": false,
+		"archive_only":           false,
+	})
+
+	r := resourceGroupSettings()
+	diff, err := r.Diff(state, config, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := r.Apply(state, diff, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if stored.AllowExternalMembers != "false" || stored.ArchiveOnly != "false" {
+		t.Errorf("expected the changed settings to be sent as false, got %+v", stored)
+	}
+}
+
+func TestResourceGroupSettings_whoCanJoinRoundTrip(t *testing.T) {
+	for _, value := range []string{"ANYONE_CAN_JOIN", "ALL_IN_DOMAIN_CAN_JOIN", "INVITED_CAN_JOIN", "CAN_REQUEST_TO_JOIN"} {
+		config, stored := testGroupSettingsServer(t)
+
+		d := schema.TestResourceDataRaw(t, resourceGroupSettings().Schema, map[string]interface{}{
+			"email":        "group@domain.ext",
+			"who_can_join": value,
+		})
+		err := resourceGroupSettingsCreate(d, config)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if stored.WhoCanJoin != value {
+			t.Errorf("expected who_can_join to be sent as %q, got %q", value, stored.WhoCanJoin)
+		}
+		if actual := d.Get("who_can_join").(string); actual != value {
+			t.Errorf("expected who_can_join to be read back as %q, got %q", value, actual)
+		}
+	}
+
+	validate := resourceGroupSettings().Schema["who_can_join"].ValidateFunc
+	if _, errs := validate("ANYONE_CAN_DISCOVER", "who_can_join"); len(errs) == 0 {
+		t.Errorf("expected an error for an invalid who_can_join")
+	}
+}
+
+func TestResourceGroupSettingsDiff_customReplyTo(t *testing.T) {
+	testCases := map[string]struct {
+		raw   map[string]interface{}
+		valid bool
+	}{
+		"custom without address": {
+			raw:   map[string]interface{}{"reply_to": "REPLY_TO_CUSTOM"},
+			valid: false,
+		},
+		"custom with address": {
+			raw:   map[string]interface{}{"reply_to": "REPLY_TO_CUSTOM", "custom_reply_to": "replies@domain.ext"},
+			valid: true,
+		},
+		"custom ignored": {
+			raw:   map[string]interface{}{"reply_to": "REPLY_TO_CUSTOM", "ignore_fields": []interface{}{"custom_reply_to"}},
+			valid: true,
+		},
+		"not custom": {
+			raw:   map[string]interface{}{"reply_to": "REPLY_TO_SENDER"},
+			valid: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		tc.raw["email"] = "group@domain.ext"
+		_, err := resourceGroupSettings().Diff(nil, terraform.NewResourceConfigRaw(tc.raw), &Config{})
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", tn, err)
+		}
+		if !tc.valid && (err == nil || !strings.Contains(err.Error(), "custom_reply_to must be set")) {
+			t.Errorf("%s: expected an error about custom_reply_to, got %v", tn, err)
+		}
+	}
+}
+
+func TestResourceGroupSettingsCreate_replyToRoundTrip(t *testing.T) {
+	config, stored := testGroupSettingsServer(t)
+
+	expected := map[string]string{
+		"reply_to":                 "REPLY_TO_CUSTOM",
+		"custom_reply_to":          "replies@domain.ext",
+		"who_can_contact_owner":    "ALL_MANAGERS_CAN_CONTACT",
+		"message_moderation_level": "MODERATE_NON_MEMBERS",
+		"spam_moderation_level":    "REJECT",
+	}
+	raw := map[string]interface{}{
+		"email": "group@domain.ext",
+	}
+	for k, v := range expected {
+		raw[k] = v
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceGroupSettings().Schema, raw)
+	if err := resourceGroupSettingsCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if stored.ReplyTo != "REPLY_TO_CUSTOM" || stored.CustomReplyTo != "replies@domain.ext" || stored.WhoCanContactOwner != "ALL_MANAGERS_CAN_CONTACT" {
+		t.Errorf("expected the reply-to and contact settings to be sent, got %+v", stored)
+	}
+	for k, v := range expected {
+		if actual := d.Get(k).(string); actual != v {
+			t.Errorf("expected %s to be read back as %q, got %q", k, v, actual)
+		}
+	}
+}
+
+func TestResourceGroupSettingsImporter(t *testing.T) {
+	config, stored := testGroupSettingsServer(t)
+
+	*stored = groupSettings.Groups{
+		Email:                "group@domain.ext",
+		AllowExternalMembers: "true",
+		AllowWebPosting:      "false",
+		ArchiveOnly:          "true",
+		IncludeCustomFooter:  "false",
+		WhoCanJoin:           "INVITED_CAN_JOIN",
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceGroupSettings().Schema, map[string]interface{}{})
+	d.SetId("Group@Domain.ext")
+
+	imported, err := resourceGroupSettingsImporter(d, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"email":                  "group@domain.ext",
+		"allow_external_members": true,
+		"allow_web_posting":      false,
+		"archive_only":           true,
+		"include_custom_footer":  false,
+		"who_can_join":           "INVITED_CAN_JOIN",
+	}
+	for k, v := range expected {
+		if actual := imported[0].Get(k); actual != v {
+			t.Errorf("expected %s to be imported as %v, got %v", k, v, actual)
+		}
+	}
+	if imported[0].Id() != "group@domain.ext" {
+		t.Errorf("expected the ID to be the lowercased email, got %s", imported[0].Id())
+	}
+}
+
+func TestAccResourceGroupSettings_import(t *testing.T) {
+	domainName := os.Getenv(testAccDomainEnvVar)
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if domainName == "" {
+				t.Skipf("%s must be set for group settings acceptance tests", testAccDomainEnvVar)
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupSettingsConfig(name, domainName),
+			},
+			{
+				ResourceName:      "gsuite_group_settings.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceGroupSettingsConfig(name, domainName string) string {
+	return fmt.Sprintf(`
+resource "gsuite_group" "test" {
+  email = "%[1]s@%[2]s"
+  name  = "%[1]s"
+}
+
+resource "gsuite_group_settings" "test" {
+  email = gsuite_group.test.email
+
+  allow_external_members = true
+  allow_web_posting      = false
+  who_can_join           = "INVITED_CAN_JOIN"
+}
+`, name, domainName)
+}
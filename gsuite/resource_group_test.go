@@ -0,0 +1,257 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// testGroupServer fakes a directory API in which the group already exists,
+// and records the calls it received.
+func testGroupServer(t *testing.T) (*Config, *[]string) {
+	var mu sync.Mutex
+	calls := []string{}
+
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/groups"):
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"error":{"code":409,"message":"Entity already exists.","errors":[{"reason":"duplicate","message":"Entity already exists."}]}}`)
+		case strings.HasSuffix(r.URL.Path, "/aliases"):
+			fmt.Fprint(w, `{"aliases":[]}`)
+		default:
+			fmt.Fprint(w, `{"id":"existing-id","email":"existing@domain.ext","name":"existing"}`)
+		}
+	})
+
+	return config, &calls
+}
+
+func TestResourceGroupCreate_updateExisting(t *testing.T) {
+	testCases := []struct {
+		updateExisting bool
+		adopted        bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, testCase := range testCases {
+		config, calls := testGroupServer(t)
+		config.UpdateExisting = testCase.updateExisting
+
+		d := schema.TestResourceDataRaw(t, resourceGroup().Schema, map[string]interface{}{
+			"email": "existing@domain.ext",
+			"name":  "existing",
+		})
+		err := resourceGroupCreate(d, config)
+
+		patched := false
+		for _, call := range *calls {
+			if strings.HasPrefix(call, http.MethodPatch+" ") && strings.HasSuffix(call, "/groups/existing-id") {
+				patched = true
+			}
+		}
+
+		if testCase.adopted {
+			if err != nil {
+				t.Fatalf("expected the existing group to be adopted, got %s", err)
+			}
+			if d.Id() != "existing-id" || !patched {
+				t.Errorf("expected the existing group to be adopted and updated, got id %q and calls %v", d.Id(), *calls)
+			}
+		} else {
+			if err == nil || !strings.Contains(err.Error(), "Entity already exists.") {
+				t.Fatalf("expected the duplicate error to be returned, got %v", err)
+			}
+			if d.Id() != "" || patched {
+				t.Errorf("expected the existing group to be left alone, got id %q and calls %v", d.Id(), *calls)
+			}
+		}
+	}
+}
+
+func TestResourceGroupCreate_readAfterWrite(t *testing.T) {
+	defer func(backoff time.Duration) { readAfterWriteBackoff = backoff }(readAfterWriteBackoff)
+	readAfterWriteBackoff = time.Millisecond
+
+	var mu sync.Mutex
+	gets := 0
+
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/aliases"):
+			fmt.Fprint(w, `{"aliases":[]}`)
+		case r.Method == http.MethodGet:
+			mu.Lock()
+			gets++
+			first := gets == 1
+			mu.Unlock()
+
+			// The group has not propagated yet on the first read
+			if first {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"code":404,"message":"Resource Not Found: groupKey","errors":[{"reason":"notFound","message":"Resource Not Found: groupKey"}]}}`)
+				return
+			}
+			fmt.Fprint(w, `{"id":"new-id","email":"new@domain.ext","name":"new"}`)
+		default:
+			fmt.Fprint(w, `{"id":"new-id","email":"new@domain.ext","name":"new"}`)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceGroup().Schema, map[string]interface{}{
+		"email": "new@domain.ext",
+		"name":  "new",
+	})
+	if err := resourceGroupCreate(d, config); err != nil {
+		t.Fatalf("expected the group to be read after it propagated, got %s", err)
+	}
+
+	if d.Id() != "new-id" {
+		t.Errorf("expected the group to be kept in state, got id %q", d.Id())
+	}
+	if gets < 2 {
+		t.Errorf("expected the read to be retried, got %d reads", gets)
+	}
+}
+
+func TestResourceGroupRead_nonEditableAliases(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/members"):
+			fmt.Fprint(w, `{"members":[]}`)
+		default:
+			fmt.Fprint(w, `{"id":"group-id","email":"team@domain.ext","name":"team","aliases":["crew@domain.ext"],"nonEditableAliases":["team@domain-alias.ext","crew@domain-alias.ext"]}`)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceGroup().Schema, map[string]interface{}{
+		"email":   "team@domain.ext",
+		"aliases": []interface{}{"crew@domain.ext"},
+	})
+	d.SetId("group-id")
+	if err := resourceGroupRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := fmt.Sprint(d.Get("non_editable_aliases")); got != "[team@domain-alias.ext crew@domain-alias.ext]" {
+		t.Errorf("expected the non-editable aliases to be read, got %s", got)
+	}
+	if got := fmt.Sprint(d.Get("aliases")); got != "[crew@domain.ext]" {
+		t.Errorf("expected the managed aliases to be kept apart, got %s", got)
+	}
+
+	// Managing one of the non-editable aliases fails the plan
+	_, err := resourceGroup().Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email":   "team@domain.ext",
+		"aliases": []interface{}{"crew@domain.ext", "Team@Domain-Alias.ext"},
+	}), config)
+	if err == nil || !strings.Contains(err.Error(), "Team@Domain-Alias.ext") {
+		t.Errorf("expected an error about the non-editable alias, got %v", err)
+	}
+
+	data := schema.TestResourceDataRaw(t, dataGroup().Schema, map[string]interface{}{
+		"email": "team@domain.ext",
+	})
+	if err := dataGroupRead(data, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := data.Get("non_editable_aliases.#").(int); got != 2 {
+		t.Errorf("expected 2 non-editable aliases in the data source, got %d", got)
+	}
+}
+
+func TestResourceGroupDiff_emailDomain(t *testing.T) {
+	listed := 0
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/customer/my_customer/domains") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		listed++
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"domains":[{"domainName":"domain.ext","verified":true,"domainAliases":[{"domainAliasName":"alias.ext","verified":true}]},{"domainName":"pending.ext","verified":false}]}`)
+	})
+	config.CustomerId = "my_customer"
+	config.customerDomainsCache = &customerDomainsCache{}
+
+	testCases := map[string]string{
+		"team@domain.ext":  "",
+		"Team@Alias.ext":   "",
+		"team@domian.ext":  "skip_domain_check",
+		"team@pending.ext": "not verified",
+	}
+	for email, expectedErr := range testCases {
+		_, err := resourceGroup().Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"email": email,
+		}), config)
+		if expectedErr == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", email, err)
+		}
+		if expectedErr != "" && (err == nil || !strings.Contains(err.Error(), expectedErr)) {
+			t.Errorf("%s: expected an error about %s, got %v", email, expectedErr, err)
+		}
+	}
+	if listed != 1 {
+		t.Errorf("expected the domains to be listed once, got %d calls", listed)
+	}
+
+	// An unchanged email isn't checked again, and the check can be skipped
+	state := &terraform.InstanceState{
+		ID: "group-id",
+		Attributes: map[string]string{
+			"id":    "group-id",
+			"email": "team@pending.ext",
+		},
+	}
+	if _, err := resourceGroup().Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email": "team@pending.ext",
+	}), config); err != nil {
+		t.Errorf("expected an unchanged email not to be checked, got %s", err)
+	}
+
+	config.SkipDomainCheck = true
+	if _, err := resourceGroup().Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email": "team@pending.ext",
+	}), config); err != nil {
+		t.Errorf("expected the check to be skipped, got %s", err)
+	}
+}
+
+func TestResourceGroupDiff_emailDomainUnlisted(t *testing.T) {
+	listed := 0
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		listed++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"code":403,"message":"Request had insufficient authentication scopes."}}`)
+	})
+	config.CustomerId = "my_customer"
+	config.customerDomainsCache = &customerDomainsCache{}
+
+	// Without a domain scope the check is skipped, listing the domains is
+	// only attempted once
+	for _, email := range []string{"team@domian.ext", "team@pending.ext"} {
+		if _, err := resourceGroup().Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"email": email,
+		}), config); err != nil {
+			t.Errorf("%s: expected the check to be skipped, got %s", email, err)
+		}
+	}
+	if listed != 1 {
+		t.Errorf("expected the domains to be listed once, got %d calls", listed)
+	}
+}
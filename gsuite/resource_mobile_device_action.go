@@ -0,0 +1,108 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// Mobile devices register themselves, so the only thing to manage is an
+// action taken on a device. The action is applied once on create, changing it
+// applies the new action, destroying the resource does not undo it.
+func resourceMobileDeviceAction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMobileDeviceActionCreate,
+		Read:   resourceMobileDeviceActionRead,
+		Delete: resourceMobileDeviceActionDelete,
+
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"admin_account_wipe", "admin_remote_wipe", "approve", "block",
+					"cancel_remote_wipe_then_activate", "cancel_remote_wipe_then_block",
+				}, false),
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"model": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMobileDeviceActionCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	resourceID := d.Get("resource_id").(string)
+	action := &directory.MobileDeviceAction{
+		Action: d.Get("action").(string),
+	}
+
+	var err error
+	err = retry(func() error {
+		err = config.directory.Mobiledevices.Action(config.CustomerId, resourceID, action).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error applying action %s to mobile device %s: %s", action.Action, resourceID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", resourceID, action.Action))
+	log.Printf("[INFO] Applied action %s to mobile device: %s", action.Action, resourceID)
+	return resourceMobileDeviceActionRead(d, meta)
+}
+
+func resourceMobileDeviceActionRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	resourceID := strings.SplitN(d.Id(), "/", 2)[0]
+
+	var device *directory.MobileDevice
+	var err error
+	err = retry(func() error {
+		device, err = config.directory.Mobiledevices.Get(config.CustomerId, resourceID).Projection("BASIC").Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if isNotFound(err) {
+		// A wiped device is eventually removed, the action has been applied and
+		// the last known status and model are kept
+		log.Printf("[WARN] Mobile device %s is gone, keeping the applied action %s", resourceID, d.Get("action").(string))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error reading Mobile device %q: %s", resourceID, err)
+	}
+
+	d.Set("resource_id", device.ResourceId)
+	d.Set("status", device.Status)
+	d.Set("model", device.Model)
+
+	return nil
+}
+
+func resourceMobileDeviceActionDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] The action on mobile device %s can't be undone, removing it from state only", d.Id())
+	d.SetId("")
+	return nil
+}
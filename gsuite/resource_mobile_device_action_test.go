@@ -0,0 +1,42 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestResourceMobileDeviceActionRead_gone(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/customer/my_customer/devices/mobile/device-id") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":404,"message":"Resource Not Found: resourceId"}}`)
+	})
+	config.CustomerId = "my_customer"
+
+	d := resourceMobileDeviceAction().Data(&terraform.InstanceState{
+		ID: "device-id/admin_remote_wipe",
+		Attributes: map[string]string{
+			"resource_id": "device-id",
+			"action":      "admin_remote_wipe",
+			"status":      "WIPING",
+			"model":       "Pixel 4",
+		},
+	})
+	if err := resourceMobileDeviceActionRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Id() != "device-id/admin_remote_wipe" {
+		t.Fatalf("expected the action to be kept in state, got ID %q", d.Id())
+	}
+	if d.Get("status").(string) != "WIPING" || d.Get("model").(string) != "Pixel 4" {
+		t.Fatalf("expected the last known status and model, got %q and %q", d.Get("status"), d.Get("model"))
+	}
+}
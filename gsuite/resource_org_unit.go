@@ -0,0 +1,286 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceOrgUnit() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceOrgUnitCreate,
+		Read:   resourceOrgUnitRead,
+		Update: resourceOrgUnitUpdate,
+		Delete: resourceOrgUnitDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceOrgUnitImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"parent_org_unit_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "/",
+				StateFunc:    orgUnitPathStateFunc,
+				ValidateFunc: validateOrgUnitPath,
+			},
+
+			"block_inheritance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"org_unit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"org_unit_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"parent_org_unit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// orgUnitKey converts an org unit path or id into the key the API expects.
+// Paths are passed without their leading slash, the client keeps the
+// remaining slashes intact. Ids are prefixed with "id:", which is also what
+// the API returns in the org_unit_id attribute.
+func orgUnitKey(pathOrID string) string {
+	if strings.HasPrefix(pathOrID, "id:") {
+		return pathOrID
+	}
+	return strings.TrimPrefix(pathOrID, "/")
+}
+
+// normalizeOrgUnitPath prepends the leading slash the API expects to an org
+// unit path, e.g. "Engineering" becomes "/Engineering".
+func normalizeOrgUnitPath(path string) string {
+	if path == "" || strings.HasPrefix(path, "/") {
+		return path
+	}
+	return "/" + path
+}
+
+func orgUnitPathStateFunc(val interface{}) string {
+	return normalizeOrgUnitPath(val.(string))
+}
+
+// validateOrgUnitPath rejects org unit paths with empty segments, like
+// "/Engineering//Back End" or "/Engineering/".
+func validateOrgUnitPath(v interface{}, k string) (warnings []string, errors []error) {
+	path := normalizeOrgUnitPath(v.(string))
+	if path == "" || path == "/" {
+		return
+	}
+
+	if strings.Contains(path, "//") {
+		errors = append(errors, fmt.Errorf("%s: org unit path %s must not contain empty segments (double slashes)", k, path))
+	}
+	if strings.HasSuffix(path, "/") {
+		errors = append(errors, fmt.Errorf("%s: org unit path %s must not end with a slash", k, path))
+	}
+
+	return
+}
+
+func resourceOrgUnitCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	orgUnit := &directory.OrgUnit{
+		Name:              d.Get("name").(string),
+		ParentOrgUnitPath: normalizeOrgUnitPath(d.Get("parent_org_unit_path").(string)),
+		BlockInheritance:  d.Get("block_inheritance").(bool),
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		log.Printf("[DEBUG] Setting %s: %s", "description", v.(string))
+		orgUnit.Description = v.(string)
+	}
+
+	var createdOrgUnit *directory.OrgUnit
+	err = retry(func() error {
+		createdOrgUnit, err = config.directory.Orgunits.Insert(customerID, orgUnit).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating org unit: %s", err)
+	}
+
+	d.SetId(createdOrgUnit.OrgUnitId)
+
+	// Try to read the org unit, retrying for 404's
+	err = retryNotFound(func() error {
+		_, err = config.directory.Orgunits.Get(customerID, orgUnitKey(d.Id())).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Taking too long to create this org unit: %s", err)
+	}
+
+	log.Printf("[INFO] Created org unit: %s", createdOrgUnit.OrgUnitPath)
+	return resourceOrgUnitRead(d, meta)
+}
+
+func resourceOrgUnitUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	orgUnit := &directory.OrgUnit{}
+	nullFields := []string{}
+	forceSendFields := []string{}
+
+	if d.HasChange("name") {
+		log.Printf("[DEBUG] Updating org unit name: %s", d.Get("name").(string))
+		orgUnit.Name = d.Get("name").(string)
+	}
+
+	if d.HasChange("description") {
+		if v, ok := d.GetOk("description"); ok {
+			log.Printf("[DEBUG] Updating org unit description: %s", v.(string))
+			orgUnit.Description = v.(string)
+		} else {
+			log.Printf("[DEBUG] Removing org unit description")
+			orgUnit.Description = ""
+			nullFields = append(nullFields, "Description")
+		}
+	}
+
+	// Moving an org unit is done in place by changing its parent
+	if d.HasChange("parent_org_unit_path") {
+		log.Printf("[DEBUG] Updating org unit parent_org_unit_path: %s", d.Get("parent_org_unit_path").(string))
+		orgUnit.ParentOrgUnitPath = normalizeOrgUnitPath(d.Get("parent_org_unit_path").(string))
+	}
+
+	if d.HasChange("block_inheritance") {
+		log.Printf("[DEBUG] Updating org unit block_inheritance: %t", d.Get("block_inheritance").(bool))
+		orgUnit.BlockInheritance = d.Get("block_inheritance").(bool)
+		forceSendFields = append(forceSendFields, "BlockInheritance")
+	}
+
+	if len(nullFields) > 0 {
+		orgUnit.NullFields = nullFields
+	}
+	if len(forceSendFields) > 0 {
+		orgUnit.ForceSendFields = forceSendFields
+	}
+
+	var updatedOrgUnit *directory.OrgUnit
+	err = retry(func() error {
+		updatedOrgUnit, err = config.directory.Orgunits.Patch(customerID, orgUnitKey(d.Id()), orgUnit).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating org unit: %s", err)
+	}
+
+	log.Printf("[INFO] Updated org unit: %s", updatedOrgUnit.OrgUnitPath)
+	return resourceOrgUnitRead(d, meta)
+}
+
+func resourceOrgUnitRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	var orgUnit *directory.OrgUnit
+	err = retry(func() error {
+		orgUnit, err = config.directory.Orgunits.Get(customerID, orgUnitKey(d.Id())).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Org unit %q", d.Get("name").(string)))
+	}
+
+	d.SetId(orgUnit.OrgUnitId)
+	d.Set("name", orgUnit.Name)
+	d.Set("description", orgUnit.Description)
+	d.Set("parent_org_unit_path", orgUnit.ParentOrgUnitPath)
+	d.Set("block_inheritance", orgUnit.BlockInheritance)
+	d.Set("org_unit_id", orgUnit.OrgUnitId)
+	d.Set("org_unit_path", orgUnit.OrgUnitPath)
+	d.Set("parent_org_unit_id", orgUnit.ParentOrgUnitId)
+	d.Set("etag", orgUnit.Etag)
+
+	return nil
+}
+
+func resourceOrgUnitDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		err = config.directory.Orgunits.Delete(customerID, orgUnitKey(d.Id())).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting org unit: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using the full org unit path or its id
+func resourceOrgUnitImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return nil, err
+	}
+
+	orgUnit, err := config.directory.Orgunits.Get(customerID, orgUnitKey(d.Id())).Do()
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error fetching org unit. Make sure the org unit exists: %s ", err)
+	}
+
+	d.SetId(orgUnit.OrgUnitId)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,70 @@
+package gsuite
+
+import (
+	"testing"
+)
+
+func TestOrgUnitKey(t *testing.T) {
+	testCases := []struct {
+		pathOrID string
+		expected string
+	}{
+		{"/Engineering", "Engineering"},
+		{"/Engineering/Back End", "Engineering/Back End"},
+		{"Engineering", "Engineering"},
+		{"id:03ph8a2z1enx4lx", "id:03ph8a2z1enx4lx"},
+	}
+
+	for _, testCase := range testCases {
+		if key := orgUnitKey(testCase.pathOrID); key != testCase.expected {
+			t.Errorf("expected %s for %s, got %s", testCase.expected, testCase.pathOrID, key)
+		}
+	}
+}
+
+func TestNormalizeOrgUnitPath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"", ""},
+		{"/", "/"},
+		{"Engineering", "/Engineering"},
+		{"/Engineering", "/Engineering"},
+		{"Engineering/Back End", "/Engineering/Back End"},
+		{"/Engineering/Back End", "/Engineering/Back End"},
+	}
+
+	for _, testCase := range testCases {
+		if path := normalizeOrgUnitPath(testCase.path); path != testCase.expected {
+			t.Errorf("expected %q for %q, got %q", testCase.expected, testCase.path, path)
+		}
+	}
+}
+
+func TestValidateOrgUnitPath(t *testing.T) {
+	testCases := []struct {
+		path  string
+		valid bool
+	}{
+		{"", true},
+		{"/", true},
+		{"Engineering", true},
+		{"/Engineering/Back End", true},
+		{"//", false},
+		{"//Engineering", false},
+		{"/Engineering//Back End", false},
+		{"/Engineering/", false},
+		{"Engineering/", false},
+	}
+
+	for _, testCase := range testCases {
+		_, errs := validateOrgUnitPath(testCase.path, "org_unit_path")
+		if testCase.valid && len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", testCase.path, errs)
+		}
+		if !testCase.valid && len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", testCase.path)
+		}
+	}
+}
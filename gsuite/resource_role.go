@@ -0,0 +1,206 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRoleCreate,
+		Read:   resourceRoleRead,
+		Update: resourceRoleUpdate,
+		Delete: resourceRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"role_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"role_description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"privilege": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"privilege_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"role_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"is_system_role": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"is_super_admin_role": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandRolePrivileges(d *schema.ResourceData) []*directory.RoleRolePrivileges {
+	privileges := []*directory.RoleRolePrivileges{}
+	for _, raw := range d.Get("privilege").(*schema.Set).List() {
+		privilege := raw.(map[string]interface{})
+		privileges = append(privileges, &directory.RoleRolePrivileges{
+			ServiceId:     privilege["service_id"].(string),
+			PrivilegeName: privilege["privilege_name"].(string),
+		})
+	}
+	return privileges
+}
+
+func flattenRolePrivileges(privileges []*directory.RoleRolePrivileges) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(privileges))
+	for _, privilege := range privileges {
+		result = append(result, map[string]interface{}{
+			"service_id":     privilege.ServiceId,
+			"privilege_name": privilege.PrivilegeName,
+		})
+	}
+	return result
+}
+
+func resourceRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	role := &directory.Role{
+		RoleName:       d.Get("role_name").(string),
+		RolePrivileges: expandRolePrivileges(d),
+	}
+
+	if v, ok := d.GetOk("role_description"); ok {
+		log.Printf("[DEBUG] Setting %s: %s", "role_description", v.(string))
+		role.RoleDescription = v.(string)
+	}
+
+	var createdRole *directory.Role
+	err = retry(func() error {
+		createdRole, err = config.directory.Roles.Insert(customerID, role).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating role: %s", err)
+	}
+
+	d.SetId(strconv.FormatInt(createdRole.RoleId, 10))
+	log.Printf("[INFO] Created role: %s", createdRole.RoleName)
+	return resourceRoleRead(d, meta)
+}
+
+func resourceRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	// Update replaces the role, so the complete configuration is sent
+	role := &directory.Role{
+		RoleName:        d.Get("role_name").(string),
+		RoleDescription: d.Get("role_description").(string),
+		RolePrivileges:  expandRolePrivileges(d),
+	}
+
+	var updatedRole *directory.Role
+	err = retry(func() error {
+		updatedRole, err = config.directory.Roles.Update(customerID, d.Id(), role).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating role: %s", err)
+	}
+
+	log.Printf("[INFO] Updated role: %s", updatedRole.RoleName)
+	return resourceRoleRead(d, meta)
+}
+
+func resourceRoleRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	var role *directory.Role
+	err = retry(func() error {
+		role, err = config.directory.Roles.Get(customerID, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Role %q", d.Get("role_name").(string)))
+	}
+
+	d.SetId(strconv.FormatInt(role.RoleId, 10))
+	d.Set("role_id", strconv.FormatInt(role.RoleId, 10))
+	d.Set("role_name", role.RoleName)
+	d.Set("role_description", role.RoleDescription)
+	d.Set("privilege", flattenRolePrivileges(role.RolePrivileges))
+	d.Set("is_system_role", role.IsSystemRole)
+	d.Set("is_super_admin_role", role.IsSuperAdminRole)
+	d.Set("etag", role.Etag)
+
+	return nil
+}
+
+func resourceRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		err = config.directory.Roles.Delete(customerID, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting role: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
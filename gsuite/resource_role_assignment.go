@@ -0,0 +1,157 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceRoleAssignment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRoleAssignmentCreate,
+		Read:   resourceRoleAssignmentRead,
+		Delete: resourceRoleAssignmentDelete,
+		// Role assignments are immutable, there is no update method
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The unique id of the user or service account the role is assigned to
+			"assigned_to": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"scope_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "CUSTOMER",
+				ValidateFunc: validation.StringInSlice([]string{"CUSTOMER", "ORG_UNIT"}, false),
+			},
+
+			// Required when scope_type is ORG_UNIT
+			"org_unit_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"role_assignment_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRoleAssignmentCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	roleID, err := strconv.ParseInt(d.Get("role_id").(string), 10, 64)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Invalid role_id %q: %s", d.Get("role_id").(string), err)
+	}
+
+	roleAssignment := &directory.RoleAssignment{
+		RoleId:     roleID,
+		AssignedTo: d.Get("assigned_to").(string),
+		ScopeType:  d.Get("scope_type").(string),
+	}
+
+	if v, ok := d.GetOk("org_unit_id"); ok {
+		log.Printf("[DEBUG] Setting %s: %s", "org_unit_id", v.(string))
+		roleAssignment.OrgUnitId = v.(string)
+	}
+
+	if roleAssignment.ScopeType == "ORG_UNIT" && roleAssignment.OrgUnitId == "" {
+		return fmt.Errorf("[ERROR] org_unit_id is required when scope_type is ORG_UNIT")
+	}
+
+	var createdRoleAssignment *directory.RoleAssignment
+	err = retry(func() error {
+		createdRoleAssignment, err = config.directory.RoleAssignments.Insert(customerID, roleAssignment).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating role assignment: %s", err)
+	}
+
+	d.SetId(strconv.FormatInt(createdRoleAssignment.RoleAssignmentId, 10))
+	log.Printf("[INFO] Created role assignment: %s", d.Id())
+	return resourceRoleAssignmentRead(d, meta)
+}
+
+func resourceRoleAssignmentRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	var roleAssignment *directory.RoleAssignment
+	err = retry(func() error {
+		roleAssignment, err = config.directory.RoleAssignments.Get(customerID, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	// Deleting the assigned user also removes its role assignments, which
+	// results in a 404 here and removes the assignment from state.
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Role assignment %q", d.Id()))
+	}
+
+	d.SetId(strconv.FormatInt(roleAssignment.RoleAssignmentId, 10))
+	d.Set("role_assignment_id", strconv.FormatInt(roleAssignment.RoleAssignmentId, 10))
+	d.Set("role_id", strconv.FormatInt(roleAssignment.RoleId, 10))
+	d.Set("assigned_to", roleAssignment.AssignedTo)
+	d.Set("scope_type", roleAssignment.ScopeType)
+	d.Set("org_unit_id", roleAssignment.OrgUnitId)
+	d.Set("etag", roleAssignment.Etag)
+
+	return nil
+}
+
+func resourceRoleAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	customerID, err := config.resolvedCustomerID()
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		err = config.directory.RoleAssignments.Delete(customerID, d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting role assignment: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -1,13 +1,18 @@
 package gsuite
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/pkg/errors"
 	"github.com/sethvargo/go-password/password"
 	directory "google.golang.org/api/admin/directory/v1"
@@ -58,6 +63,9 @@ func flattenCustomSchema(schema map[string]googleapi.RawMessage) (error, []map[s
 		}
 
 		err, value = orderValues(value)
+		if err != nil {
+			return err, result
+		}
 
 		customSchemaMap["value"] = value
 		result = append(result, customSchemaMap)
@@ -84,7 +92,9 @@ func (s customValues) Less(i, j int) bool {
 		return valuei < valuej
 	}
 
-	return true
+	// Multi-valued BOOL, INT64 and DOUBLE fields don't hold strings, compare
+	// their printed form to keep the order stable
+	return fmt.Sprint(s[i]["value"]) < fmt.Sprint(s[j]["value"])
 }
 
 func orderValues(j string) (error, string) {
@@ -93,6 +103,9 @@ func orderValues(j string) (error, string) {
 	orderedSchemaValue := map[string]interface{}{}
 
 	err := json.Unmarshal([]byte(j), &schemaValue)
+	if err != nil {
+		return err, ""
+	}
 
 	for key, values := range schemaValue {
 		// Let's sort the values objects array by "value" key to prevent diffs on every plan
@@ -119,6 +132,756 @@ func orderValues(j string) (error, string) {
 	return err, string(s)
 }
 
+// customSchemaValueDiffSuppress ignores formatting and the ordering of
+// multi-valued fields, the API doesn't preserve either of them.
+func customSchemaValueDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	errOld, orderedOld := orderValues(old)
+	errNew, orderedNew := orderValues(new)
+	if errOld != nil || errNew != nil {
+		return false
+	}
+	return orderedOld == orderedNew
+}
+
+// expandCustomSchemas builds the CustomSchemas map of a user, every value is
+// the JSON encoded object of field values of that schema.
+func expandCustomSchemas(d *schema.ResourceData) map[string]googleapi.RawMessage {
+	customSchemas := map[string]googleapi.RawMessage{}
+	for i := 0; i < d.Get("custom_schema.#").(int); i++ {
+		entry := d.Get(fmt.Sprintf("custom_schema.%d", i)).(map[string]interface{})
+		customSchemas[entry["name"].(string)] = []byte(entry["value"].(string))
+	}
+	return customSchemas
+}
+
+// decodeUserField decodes one of the JSON arrays of a user, such as its
+// phones or addresses, into target.
+func decodeUserField(v interface{}, target interface{}) error {
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, target)
+}
+
+func expandUserOrganizations(list []interface{}) []*directory.UserOrganization {
+	organizations := []*directory.UserOrganization{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		organizations = append(organizations, &directory.UserOrganization{
+			CostCenter:         entry["cost_center"].(string),
+			CustomType:         entry["custom_type"].(string),
+			Department:         entry["department"].(string),
+			Description:        entry["description"].(string),
+			Domain:             entry["domain"].(string),
+			FullTimeEquivalent: int64(entry["full_time_equivalent"].(int)),
+			Location:           entry["location"].(string),
+			Name:               entry["name"].(string),
+			Primary:            entry["primary"].(bool),
+			Symbol:             entry["symbol"].(string),
+			Title:              entry["title"].(string),
+			Type:               entry["type"].(string),
+		})
+	}
+	return organizations
+}
+
+func flattenUserOrganizations(v interface{}) ([]map[string]interface{}, error) {
+	var organizations []*directory.UserOrganization
+	if err := decodeUserField(v, &organizations); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user organizations: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(organizations))
+	for _, organization := range organizations {
+		flattened = append(flattened, map[string]interface{}{
+			"cost_center":          organization.CostCenter,
+			"custom_type":          organization.CustomType,
+			"department":           organization.Department,
+			"description":          organization.Description,
+			"domain":               organization.Domain,
+			"full_time_equivalent": int(organization.FullTimeEquivalent),
+			"location":             organization.Location,
+			"name":                 organization.Name,
+			"primary":              organization.Primary,
+			"symbol":               organization.Symbol,
+			"title":                organization.Title,
+			"type":                 organization.Type,
+		})
+	}
+	return flattened, nil
+}
+
+func expandUserExternalIds(list []interface{}) []*directory.UserExternalId {
+	externalIDs := []*directory.UserExternalId{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		externalIDs = append(externalIDs, &directory.UserExternalId{
+			CustomType: entry["custom_type"].(string),
+			Type:       entry["type"].(string),
+			Value:      entry["value"].(string),
+		})
+	}
+	return externalIDs
+}
+
+func flattenUserExternalIds(v interface{}) ([]map[string]interface{}, error) {
+	var externalIDs []*directory.UserExternalId
+	if err := decodeUserField(v, &externalIDs); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user external_ids: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(externalIDs))
+	for _, externalID := range externalIDs {
+		flattened = append(flattened, map[string]interface{}{
+			"custom_type": externalID.CustomType,
+			"type":        externalID.Type,
+			"value":       externalID.Value,
+		})
+	}
+	return flattened, nil
+}
+
+func expandUserRelations(list []interface{}) []*directory.UserRelation {
+	relations := []*directory.UserRelation{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		relations = append(relations, &directory.UserRelation{
+			CustomType: entry["custom_type"].(string),
+			Type:       entry["type"].(string),
+			Value:      entry["value"].(string),
+		})
+	}
+	return relations
+}
+
+func flattenUserRelations(v interface{}) ([]map[string]interface{}, error) {
+	var relations []*directory.UserRelation
+	if err := decodeUserField(v, &relations); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user relations: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(relations))
+	for _, relation := range relations {
+		flattened = append(flattened, map[string]interface{}{
+			"custom_type": relation.CustomType,
+			"type":        relation.Type,
+			"value":       relation.Value,
+		})
+	}
+	return flattened, nil
+}
+
+// userLanguage is a language of a user, the API client doesn't know the
+// preference of a language yet.
+type userLanguage struct {
+	LanguageCode   string `json:"languageCode,omitempty"`
+	CustomLanguage string `json:"customLanguage,omitempty"`
+	Preference     string `json:"preference,omitempty"`
+}
+
+func expandUserLanguages(list []interface{}) []*userLanguage {
+	languages := []*userLanguage{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		languages = append(languages, &userLanguage{
+			LanguageCode:   entry["language_code"].(string),
+			CustomLanguage: entry["custom_language"].(string),
+			Preference:     entry["preference"].(string),
+		})
+	}
+	return languages
+}
+
+func flattenUserLanguages(v interface{}) ([]map[string]interface{}, error) {
+	var languages []*userLanguage
+	if err := decodeUserField(v, &languages); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user languages: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(languages))
+	for _, language := range languages {
+		flattened = append(flattened, map[string]interface{}{
+			"language_code":   language.LanguageCode,
+			"custom_language": language.CustomLanguage,
+			"preference":      language.Preference,
+		})
+	}
+	return flattened, nil
+}
+
+// validateUserLanguages checks that every language is either a language code
+// or a custom language.
+func validateUserLanguages(list []interface{}) error {
+	for i, v := range list {
+		entry, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if entry["language_code"].(string) != "" && entry["custom_language"].(string) != "" {
+			return fmt.Errorf("languages.%d: only one of language_code or custom_language can be set", i)
+		}
+	}
+	return nil
+}
+
+func expandUserKeywords(list []interface{}) []*directory.UserKeyword {
+	keywords := []*directory.UserKeyword{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		keywords = append(keywords, &directory.UserKeyword{
+			CustomType: entry["custom_type"].(string),
+			Type:       entry["type"].(string),
+			Value:      entry["value"].(string),
+		})
+	}
+	return keywords
+}
+
+func flattenUserKeywords(v interface{}) ([]map[string]interface{}, error) {
+	var keywords []*directory.UserKeyword
+	if err := decodeUserField(v, &keywords); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user keywords: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(keywords))
+	for _, keyword := range keywords {
+		flattened = append(flattened, map[string]interface{}{
+			"custom_type": keyword.CustomType,
+			"type":        keyword.Type,
+			"value":       keyword.Value,
+		})
+	}
+	return flattened, nil
+}
+
+func expandUserWebsites(list []interface{}) []*directory.UserWebsite {
+	websites := []*directory.UserWebsite{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		websites = append(websites, &directory.UserWebsite{
+			CustomType: entry["custom_type"].(string),
+			Type:       entry["type"].(string),
+			Value:      entry["value"].(string),
+			Primary:    entry["primary"].(bool),
+		})
+	}
+	return websites
+}
+
+func flattenUserWebsites(v interface{}) ([]map[string]interface{}, error) {
+	var websites []*directory.UserWebsite
+	if err := decodeUserField(v, &websites); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user websites: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(websites))
+	for _, website := range websites {
+		flattened = append(flattened, map[string]interface{}{
+			"custom_type": website.CustomType,
+			"type":        website.Type,
+			"value":       website.Value,
+			"primary":     website.Primary,
+		})
+	}
+	return flattened, nil
+}
+
+func expandUserIms(list []interface{}) []*directory.UserIm {
+	ims := []*directory.UserIm{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		ims = append(ims, &directory.UserIm{
+			CustomProtocol: entry["custom_protocol"].(string),
+			CustomType:     entry["custom_type"].(string),
+			Im:             entry["im"].(string),
+			Protocol:       entry["protocol"].(string),
+			Type:           entry["type"].(string),
+			Primary:        entry["primary"].(bool),
+		})
+	}
+	return ims
+}
+
+func flattenUserIms(v interface{}) ([]map[string]interface{}, error) {
+	var ims []*directory.UserIm
+	if err := decodeUserField(v, &ims); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user ims: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(ims))
+	for _, im := range ims {
+		flattened = append(flattened, map[string]interface{}{
+			"custom_protocol": im.CustomProtocol,
+			"custom_type":     im.CustomType,
+			"im":              im.Im,
+			"protocol":        im.Protocol,
+			"type":            im.Type,
+			"primary":         im.Primary,
+		})
+	}
+	return flattened, nil
+}
+
+func expandUserGender(list []interface{}) *directory.UserGender {
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+
+	entry := list[0].(map[string]interface{})
+	return &directory.UserGender{
+		Type:         entry["type"].(string),
+		CustomGender: entry["custom_gender"].(string),
+		AddressMeAs:  entry["address_me_as"].(string),
+	}
+}
+
+func flattenUserGender(v interface{}) ([]map[string]interface{}, error) {
+	var gender *directory.UserGender
+	if err := decodeUserField(v, &gender); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user gender: %s", err)
+	}
+
+	if gender == nil {
+		return nil, nil
+	}
+	return []map[string]interface{}{{
+		"type":          gender.Type,
+		"custom_gender": gender.CustomGender,
+		"address_me_as": gender.AddressMeAs,
+	}}, nil
+}
+
+// validateUserGender checks that a custom gender is only set for the other
+// type.
+func validateUserGender(list []interface{}) error {
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+
+	entry := list[0].(map[string]interface{})
+	if entry["custom_gender"].(string) != "" && entry["type"].(string) != "other" {
+		return fmt.Errorf("gender.0.custom_gender can only be set when the type is other, got %s", entry["type"].(string))
+	}
+	return nil
+}
+
+func expandUserPosixAccounts(list []interface{}) []*directory.UserPosixAccount {
+	posixAccounts := []*directory.UserPosixAccount{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		posixAccount := &directory.UserPosixAccount{
+			Gecos:         entry["gecos"].(string),
+			Gid:           uint64(entry["gid"].(int)),
+			HomeDirectory: entry["home_directory"].(string),
+			Primary:       entry["primary"].(bool),
+			Shell:         entry["shell"].(string),
+			SystemId:      entry["system_id"].(string),
+			Uid:           uint64(entry["uid"].(int)),
+			Username:      entry["username"].(string),
+		}
+		posixAccounts = append(posixAccounts, posixAccount)
+	}
+	return posixAccounts
+}
+
+func flattenUserPosixAccounts(v interface{}) ([]map[string]interface{}, error) {
+	var posixAccounts []*directory.UserPosixAccount
+	if err := decodeUserField(v, &posixAccounts); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user posix_accounts: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(posixAccounts))
+	for _, posixAccount := range posixAccounts {
+		flattened = append(flattened, map[string]interface{}{
+			"account_id":     posixAccount.AccountId,
+			"gecos":          posixAccount.Gecos,
+			"gid":            int(posixAccount.Gid),
+			"home_directory": posixAccount.HomeDirectory,
+			"primary":        posixAccount.Primary,
+			"shell":          posixAccount.Shell,
+			"system_id":      posixAccount.SystemId,
+			"uid":            int(posixAccount.Uid),
+			"username":       posixAccount.Username,
+		})
+	}
+	return flattened, nil
+}
+
+func expandUserSSHPublicKeys(list []interface{}) []*directory.UserSshPublicKey {
+	sshPublicKeys := []*directory.UserSshPublicKey{}
+	for i, v := range list {
+		entry := v.(map[string]interface{})
+		sshPublicKey := &directory.UserSshPublicKey{
+			ExpirationTimeUsec: int64(entry["expiration_time_usec"].(int)),
+			Key:                strings.TrimSpace(entry["key"].(string)),
+		}
+		log.Printf("[DEBUG] Setting ssh %d key: %s", i, sshPublicKeyFingerprint(sshPublicKey.Key))
+		sshPublicKeys = append(sshPublicKeys, sshPublicKey)
+	}
+	return sshPublicKeys
+}
+
+// sshPublicKeyFingerprint returns the SHA256 fingerprint of an authorized_keys
+// line, so keys can be told apart in the logs without logging them.
+func sshPublicKeyFingerprint(key string) string {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return "invalid key"
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "invalid key"
+	}
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+func flattenUserSSHPublicKeys(v interface{}) ([]map[string]interface{}, error) {
+	var sshPublicKeys []*directory.UserSshPublicKey
+	if err := decodeUserField(v, &sshPublicKeys); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user ssh_public_keys: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(sshPublicKeys))
+	for _, sshPublicKey := range sshPublicKeys {
+		flattened = append(flattened, map[string]interface{}{
+			"expiration_time_usec": int(sshPublicKey.ExpirationTimeUsec),
+			"fingerprint":          sshPublicKey.Fingerprint,
+			"key":                  sshPublicKey.Key,
+		})
+	}
+	return flattened, nil
+}
+
+// sshPublicKeyDiffSuppress ignores whitespace around a key, such as the
+// trailing newline of a key read from a file, which is not sent to the API.
+func sshPublicKeyDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+func expandUserPhones(list []interface{}) []*directory.UserPhone {
+	phones := []*directory.UserPhone{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		phones = append(phones, &directory.UserPhone{
+			CustomType: entry["custom_type"].(string),
+			Primary:    entry["primary"].(bool),
+			Type:       entry["type"].(string),
+			Value:      entry["value"].(string),
+		})
+	}
+	return phones
+}
+
+func flattenUserPhones(v interface{}) ([]map[string]interface{}, error) {
+	var phones []*directory.UserPhone
+	if err := decodeUserField(v, &phones); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user phones: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(phones))
+	for _, phone := range phones {
+		flattened = append(flattened, map[string]interface{}{
+			"custom_type": phone.CustomType,
+			"primary":     phone.Primary,
+			"type":        phone.Type,
+			"value":       phone.Value,
+		})
+	}
+	return flattened, nil
+}
+
+func expandUserAddresses(list []interface{}) []*directory.UserAddress {
+	addresses := []*directory.UserAddress{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		addresses = append(addresses, &directory.UserAddress{
+			Country:            entry["country"].(string),
+			CountryCode:        entry["country_code"].(string),
+			CustomType:         entry["custom_type"].(string),
+			ExtendedAddress:    entry["extended_address"].(string),
+			Formatted:          entry["formatted"].(string),
+			Locality:           entry["locality"].(string),
+			PoBox:              entry["po_box"].(string),
+			PostalCode:         entry["postal_code"].(string),
+			Primary:            entry["primary"].(bool),
+			Region:             entry["region"].(string),
+			SourceIsStructured: entry["source_is_structured"].(bool),
+			StreetAddress:      entry["street_address"].(string),
+			Type:               entry["type"].(string),
+		})
+	}
+	return addresses
+}
+
+func flattenUserAddresses(v interface{}) ([]map[string]interface{}, error) {
+	var addresses []*directory.UserAddress
+	if err := decodeUserField(v, &addresses); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user addresses: %s", err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(addresses))
+	for _, address := range addresses {
+		flattened = append(flattened, map[string]interface{}{
+			"country":              address.Country,
+			"country_code":         address.CountryCode,
+			"custom_type":          address.CustomType,
+			"extended_address":     address.ExtendedAddress,
+			"formatted":            address.Formatted,
+			"locality":             address.Locality,
+			"po_box":               address.PoBox,
+			"postal_code":          address.PostalCode,
+			"primary":              address.Primary,
+			"region":               address.Region,
+			"source_is_structured": address.SourceIsStructured,
+			"street_address":       address.StreetAddress,
+			"type":                 address.Type,
+		})
+	}
+	return flattened, nil
+}
+
+func expandUserEmails(list []interface{}) []*directory.UserEmail {
+	emails := []*directory.UserEmail{}
+	for _, v := range list {
+		entry := v.(map[string]interface{})
+		emails = append(emails, &directory.UserEmail{
+			Address:    strings.ToLower(entry["address"].(string)),
+			CustomType: entry["custom_type"].(string),
+			Type:       entry["type"].(string),
+		})
+	}
+	return emails
+}
+
+// flattenUserAliases only keeps the aliases of the user which are in state, the
+// other aliases are managed by gsuite_user_alias.
+func flattenUserAliases(d *schema.ResourceData, user *directory.User) []string {
+	current := d.Get("aliases").(*schema.Set)
+	aliases := []string{}
+	for _, alias := range user.Aliases {
+		if current.Contains(alias) {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// flattenUserEmails leaves out the primary email and the aliases of the user,
+// the API adds them to the emails on its own.
+func flattenUserEmails(v interface{}, user *directory.User) ([]map[string]interface{}, error) {
+	var emails []*directory.UserEmail
+	if err := decodeUserField(v, &emails); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding user emails: %s", err)
+	}
+
+	managed := map[string]bool{strings.ToLower(user.PrimaryEmail): true}
+	for _, alias := range append(user.Aliases, user.NonEditableAliases...) {
+		managed[strings.ToLower(alias)] = true
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(emails))
+	for _, email := range emails {
+		if email.Primary || managed[strings.ToLower(email.Address)] {
+			continue
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"address":     strings.ToLower(email.Address),
+			"custom_type": email.CustomType,
+			"type":        email.Type,
+		})
+	}
+	return flattened, nil
+}
+
+// flattenUserBlocks sets the posix accounts, ssh public keys, external ids,
+// relations, languages, keywords, websites, ims, gender, organizations,
+// phones, addresses and emails of a user in the state.
+func flattenUserBlocks(d *schema.ResourceData, user *directory.User) error {
+	posixAccounts, err := flattenUserPosixAccounts(user.PosixAccounts)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("posix_accounts", posixAccounts); err != nil {
+		return fmt.Errorf("Error setting posix_accounts in state: %s", err.Error())
+	}
+
+	sshPublicKeys, err := flattenUserSSHPublicKeys(user.SshPublicKeys)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("ssh_public_keys", sshPublicKeys); err != nil {
+		return fmt.Errorf("Error setting ssh_public_keys in state: %s", err.Error())
+	}
+
+	externalIDs, err := flattenUserExternalIds(user.ExternalIds)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("external_ids", externalIDs); err != nil {
+		return fmt.Errorf("Error setting external_ids in state: %s", err.Error())
+	}
+
+	relations, err := flattenUserRelations(user.Relations)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("relations", relations); err != nil {
+		return fmt.Errorf("Error setting relations in state: %s", err.Error())
+	}
+
+	languages, err := flattenUserLanguages(user.Languages)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("languages", languages); err != nil {
+		return fmt.Errorf("Error setting languages in state: %s", err.Error())
+	}
+
+	keywords, err := flattenUserKeywords(user.Keywords)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("keywords", keywords); err != nil {
+		return fmt.Errorf("Error setting keywords in state: %s", err.Error())
+	}
+
+	websites, err := flattenUserWebsites(user.Websites)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("websites", websites); err != nil {
+		return fmt.Errorf("Error setting websites in state: %s", err.Error())
+	}
+
+	ims, err := flattenUserIms(user.Ims)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("ims", ims); err != nil {
+		return fmt.Errorf("Error setting ims in state: %s", err.Error())
+	}
+
+	gender, err := flattenUserGender(user.Gender)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("gender", gender); err != nil {
+		return fmt.Errorf("Error setting gender in state: %s", err.Error())
+	}
+
+	organizations, err := flattenUserOrganizations(user.Organizations)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("organizations", organizations); err != nil {
+		return fmt.Errorf("Error setting organizations in state: %s", err.Error())
+	}
+
+	phones, err := flattenUserPhones(user.Phones)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("phones", phones); err != nil {
+		return fmt.Errorf("Error setting phones in state: %s", err.Error())
+	}
+
+	addresses, err := flattenUserAddresses(user.Addresses)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("addresses", addresses); err != nil {
+		return fmt.Errorf("Error setting addresses in state: %s", err.Error())
+	}
+
+	emails, err := flattenUserEmails(user.Emails, user)
+	if err != nil {
+		return err
+	}
+	if err = d.Set("emails", emails); err != nil {
+		return fmt.Errorf("Error setting emails in state: %s", err.Error())
+	}
+
+	return nil
+}
+
+// userHashFunctions are the hash functions the API accepts for pre-hashed
+// passwords.
+var userHashFunctions = []string{"MD5", "SHA-1", "crypt"}
+
+var userPasswordHashRegexps = map[string]*regexp.Regexp{
+	"MD5":   regexp.MustCompile(`^[0-9a-fA-F]{32}$`),
+	"SHA-1": regexp.MustCompile(`^[0-9a-fA-F]{40}$`),
+	// Either a traditional DES hash or a modular crypt hash, e.g. $6$salt$hash
+	"crypt": regexp.MustCompile(`^([./0-9A-Za-z]{13}|\$[0-9a-z]+\$.+\$[./0-9A-Za-z]+)$`),
+}
+
+// validateUserPasswordHash checks that a hashed password has the format of its
+// hash function.
+func validateUserPasswordHash(hashFunction, password string) error {
+	re, ok := userPasswordHashRegexps[hashFunction]
+	if !ok || password == "" {
+		return nil
+	}
+
+	if !re.MatchString(password) {
+		switch hashFunction {
+		case "MD5":
+			return fmt.Errorf("password: expected a hex encoded MD5 hash of 32 characters, got %d characters", len(password))
+		case "SHA-1":
+			return fmt.Errorf("password: expected a hex encoded SHA-1 hash of 40 characters, got %d characters", len(password))
+		default:
+			return fmt.Errorf("password: expected a hash in the crypt(3) format")
+		}
+	}
+
+	return nil
+}
+
+// generateUserPassword generates a random password of 32 characters with
+// lower and upper case letters, digits and symbols, which meets the password
+// requirements of any domain.
+func generateUserPassword() (string, error) {
+	for {
+		generated, err := password.Generate(32, 4, 4, false, false)
+		if err != nil {
+			return "", err
+		}
+		// Only the digits and symbols are guaranteed, the letters are random
+		if strings.ContainsAny(generated, password.LowerLetters) && strings.ContainsAny(generated, password.UpperLetters) {
+			return generated, nil
+		}
+	}
+}
+
+func resourceUserCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateUserLanguages(d.Get("languages").([]interface{})); err != nil {
+		return err
+	}
+	if err := validateUserGender(d.Get("gender").([]interface{})); err != nil {
+		return err
+	}
+	// The generated password is only meant to be used once
+	if v, ok := d.GetOkExists("change_password_at_next_login"); ok && d.Id() == "" && d.Get("generate_password").(bool) && !v.(bool) {
+		return fmt.Errorf("generate_password requires change_password_at_next_login to be true")
+	}
+
+	if err := checkEmailDomain(d, "primary_email", meta.(*Config)); err != nil {
+		return err
+	}
+
+	// The password may not be known yet when it is interpolated
+	if !d.NewValueKnown("password") || !d.NewValueKnown("hash_function") {
+		return nil
+	}
+
+	return validateUserPasswordHash(d.Get("hash_function").(string), d.Get("password").(string))
+}
+
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceUserCreate,
@@ -129,11 +892,31 @@ func resourceUser() *schema.Resource {
 			State: resourceUserImporter,
 		},
 
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: resourceUserCustomizeDiff,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			// Manage the user as another admin than the provider's
+			"impersonated_user_email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEmail,
+			},
+
+			// Aliases are also returned when they're managed by gsuite_user_alias,
+			// so only the aliases added through this argument are kept in state and
+			// reconciled. Removing the argument keeps the aliases, an empty list
+			// removes them.
 			"aliases": {
 				Type:     schema.TypeSet,
 				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					StateFunc:        lowercaseEmail,
+					DiffSuppressFunc: emailDiffSuppress,
+					ValidateFunc:     validateEmail,
+				},
+				Set: hashLowercaseEmail,
 			},
 
 			"agreed_to_terms": {
@@ -161,10 +944,23 @@ func resourceUser() *schema.Resource {
 				Computed: true,
 			},
 
+			// The user is hidden when either of them is false, both are read
+			// back from the same API field.
+			"include_in_global_address_list": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          true,
+				ConflictsWith:    []string{"include_in_global_list"},
+				DiffSuppressFunc: includeInGlobalAddressListDiffSuppress,
+			},
+
 			"include_in_global_list": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  true,
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          true,
+				Deprecated:       "Use include_in_global_address_list instead",
+				ConflictsWith:    []string{"include_in_global_address_list"},
+				DiffSuppressFunc: includeInGlobalAddressListDiffSuppress,
 			},
 
 			"is_ip_whitelisted": {
@@ -193,6 +989,22 @@ func resourceUser() *schema.Resource {
 				Computed: true,
 			},
 
+			// Read-only flags reported by the API, for auditing.
+			"ip_whitelisted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"is_enforced_in_2sv": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"is_enrolled_in_2sv": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
 			"is_mailbox_setup": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -208,11 +1020,243 @@ func resourceUser() *schema.Resource {
 				Required: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"family_name": {
+						"family_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"given_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			// The password is never returned by the API
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"hash_function": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(userHashFunctions, false),
+			},
+
+			// It can't be read back, new users default to true. State from
+			// before it was tracked lacks it, which is treated like true so
+			// that existing users aren't asked to change their password.
+			"change_password_at_next_login": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != "" && old == "" && new == "true"
+				},
+			},
+
+			// Expose the random password a new user gets when no password is
+			// set, it only applies on creation
+			"generate_password": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"password"},
+			},
+
+			// Never read from the API, it stays as generated on creation
+			"generated_password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"posix_accounts": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"gecos": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"gid": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"home_directory": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"shell": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"system_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"primary": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"uid": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"primary_email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+			},
+
+			"recovery_email": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
+			},
+
+			"recovery_phone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePhoneE164,
+			},
+
+			"org_unit_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "/",
+				StateFunc:    orgUnitPathStateFunc,
+				ValidateFunc: validateOrgUnitPath,
+			},
+
+			"ssh_public_keys": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"expiration_time_usec": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"key": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validateSSHPublicKey,
+							DiffSuppressFunc: sshPublicKeyDiffSuppress,
+						},
+						"fingerprint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"is_suspended": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// Archived users keep their data but need an archived user license.
+			"archived": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// Set by Google, either when suspending the user or automatically
+			// (for example abuse or a missing agreement to the terms).
+			"suspension_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"custom_schema": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: customSchemaValueDiffSuppress,
+						},
+					},
+				},
+			},
+			"external_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"custom_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"account", "custom", "customer", "login_id", "network", "organization",
+							}, false),
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			// The value of a relation is the email address of the related
+			// person, e.g. the manager which drives the org chart.
+			"relations": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"custom_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"type": {
 							Type:     schema.TypeString,
 							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"admin_assistant", "assistant", "brother", "child", "custom",
+								"domestic_partner", "dotted_line_manager", "exec_assistant", "father",
+								"friend", "manager", "mother", "parent", "partner", "referred_by",
+								"relative", "sister", "spouse",
+							}, false),
 						},
-						"given_name": {
+						"value": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
@@ -220,143 +1264,214 @@ func resourceUser() *schema.Resource {
 				},
 			},
 
-			"password": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			// md5, sha-1 and crypt
-			"hash_function": {
-				Type:     schema.TypeString,
+			// Either the language code or a custom language, preferred or
+			// not_preferred
+			"languages": {
+				Type:     schema.TypeList,
 				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"language_code": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateLanguageCode,
+						},
+						"custom_language": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"preference": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"preferred", "not_preferred"}, false),
+						},
+					},
+				},
 			},
 
-			"posix_accounts": {
+			"keywords": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"account_id": {
+						"custom_type": {
 							Type:     schema.TypeString,
-							Computed: true,
+							Optional: true,
 						},
-						"gecos": {
+						"type": {
 							Type:     schema.TypeString,
-							Optional: true,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"custom", "mission", "occupation", "outlook",
+							}, false),
 						},
-						"gid": {
-							Type:     schema.TypeInt,
-							Optional: true,
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
 						},
-						"home_directory": {
+					},
+				},
+			},
+
+			"websites": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"custom_type": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"shell": {
+						"type": {
 							Type:     schema.TypeString,
-							Optional: true,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"app_install_page", "blog", "custom", "ftp", "home", "home_page",
+								"other", "profile", "reservations", "resume", "work",
+							}, false),
 						},
-						"system_id": {
+						"value": {
 							Type:     schema.TypeString,
-							Optional: true,
+							Required: true,
 						},
 						"primary": {
 							Type:     schema.TypeBool,
 							Optional: true,
-							Default:  false,
-						},
-						"uid": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
-						"username": {
-							Type:     schema.TypeString,
-							Optional: true,
 						},
 					},
 				},
 			},
 
-			"primary_email": {
-				Type:     schema.TypeString,
-				Required: true,
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
-			},
-
-			"recovery_email": {
-				Type:     schema.TypeString,
-				Optional: true,
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
-			},
-
-			"recovery_phone": {
-				Type:     schema.TypeString,
-				Optional: true,
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
-			},
-
-			"org_unit_path": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "/",
-			},
-
-			"ssh_public_keys": {
+			// The protocol is custom_protocol for protocols the API doesn't know,
+			// named in custom_protocol
+			"ims": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"expiration_time_usec": {
-							Type:     schema.TypeInt,
+						"custom_protocol": {
+							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"key": {
+						"custom_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"im": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
-						"fingerprint": {
+						"protocol": {
 							Type:     schema.TypeString,
-							Computed: true,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"aim", "custom_protocol", "gtalk", "icq", "jabber", "msn",
+								"net_meeting", "qq", "skype", "yahoo",
+							}, false),
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"custom", "home", "other", "work",
+							}, false),
+						},
+						"primary": {
+							Type:     schema.TypeBool,
+							Optional: true,
 						},
 					},
 				},
 			},
 
-			"is_suspended": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
-			},
-
-			"suspension_reason": {
-				Type:     schema.TypeString,
+			// A custom gender is only allowed for the other type
+			"gender": {
+				Type:     schema.TypeList,
 				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"female", "male", "other", "unknown",
+							}, false),
+						},
+						"custom_gender": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						// How the user wants to be addressed, e.g. they/them
+						"address_me_as": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
 			},
 
-			"custom_schema": {
+			"organizations": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"cost_center": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"custom_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"department": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"domain": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"full_time_equivalent": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"location": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
 						"name": {
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
 						},
-						"value": {
+						"primary": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"symbol": {
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
 						},
 					},
 				},
 			},
-			"external_ids": {
-				Type:     schema.TypeList,
+			// Phones, addresses and emails have no meaningful order, the
+			// organizations are kept as a list for compatibility with
+			// existing state.
+			"phones": {
+				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -364,9 +1479,19 @@ func resourceUser() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"primary": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
 						"type": {
 							Type:     schema.TypeString,
 							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"assistant", "callback", "car", "company_main", "custom",
+								"grand_central", "home", "home_fax", "isdn", "main", "mobile",
+								"other", "other_fax", "pager", "radio", "telex", "tty_tdd",
+								"work", "work_fax", "work_mobile", "work_pager",
+							}, false),
 						},
 						"value": {
 							Type:     schema.TypeString,
@@ -375,40 +1500,41 @@ func resourceUser() *schema.Resource {
 					},
 				},
 			},
-			"organizations": {
-				Type:     schema.TypeList,
+
+			"addresses": {
+				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"cost_center": {
+						"country": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"custom_type": {
+						"country_code": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"department": {
+						"custom_type": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"description": {
+						"extended_address": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"domain": {
+						"formatted": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"full_time_equivalent": {
-							Type:     schema.TypeInt,
+						"locality": {
+							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"location": {
+						"po_box": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"name": {
+						"postal_code": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
@@ -416,38 +1542,74 @@ func resourceUser() *schema.Resource {
 							Type:     schema.TypeBool,
 							Optional: true,
 						},
-						"symbol": {
+						"region": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
-						"title": {
+						"source_is_structured": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"street_address": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
 						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"custom", "home", "other", "work"}, false),
+						},
+					},
+				},
+			},
+
+			// The primary email and aliases are managed through primary_email
+			// and aliases, only additional emails are listed here.
+			"emails": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:             schema.TypeString,
+							Required:         true,
+							StateFunc:        lowercaseEmail,
+							DiffSuppressFunc: emailDiffSuppress,
+							ValidateFunc:     validateEmail,
+						},
+						"custom_type": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"custom", "home", "other", "work"}, false),
+						},
 					},
 				},
 			},
+
 			"update_existing": {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
-		},
+		}, schemaUserRead),
 	}
 }
 
 func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
+	config, err := impersonatedConfig(d, meta)
+	if err != nil {
+		return err
+	}
 
 	user := &directory.User{}
 	aliases := []string{}
 
 	if v, ok := d.GetOk("aliases"); ok {
 		for _, alias := range v.(*schema.Set).List() {
-			aliases = append(aliases, alias.(string))
+			aliases = append(aliases, strings.ToLower(alias.(string)))
 		}
 		log.Printf("[DEBUG] Setting %s: %v", "aliases", aliases)
 	}
@@ -465,121 +1627,43 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	if v, ok := d.GetOk("recovery_phone"); ok {
 		log.Printf("[DEBUG] Setting %s: %s", "recovery_phone", v.(string))
-		user.RecoveryPhone = strings.ToLower(v.(string))
+		user.RecoveryPhone = v.(string)
 	}
 	if v, ok := d.GetOk("org_unit_path"); ok {
 		log.Printf("[DEBUG] Setting %s: %s", "org_unit_path", v.(string))
-		user.OrgUnitPath = v.(string)
-	}
-	if v, ok := d.GetOk("suspension_reason"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "suspension_reason", v.(string))
-		user.SuspensionReason = v.(string)
+		user.OrgUnitPath = normalizeOrgUnitPath(v.(string))
 	}
 
-	if v, ok := d.GetOk("include_in_global_list"); ok {
-		log.Printf("[DEBUG] Setting %s: %t", "include_in_global_list", v.(bool))
-		user.IncludeInGlobalAddressList = v.(bool)
-	}
+	includeInGlobalAddressList := userIncludeInGlobalAddressList(d)
+	log.Printf("[DEBUG] Setting %s: %t", "include_in_global_address_list", includeInGlobalAddressList)
+	setUserIncludeInGlobalAddressList(user, includeInGlobalAddressList)
+
 	if v, ok := d.GetOk("is_ip_whitelisted"); ok {
 		log.Printf("[DEBUG] Setting %s: %t", "is_ip_whitelisted", v.(bool))
 		user.IpWhitelisted = v.(bool)
 	}
-	if v, ok := d.GetOk("is_suspended"); ok {
-		log.Printf("[DEBUG] Setting %s: %t", "is_suspended", v.(bool))
-		user.Suspended = v.(bool)
-	}
-
-	userSSHs := []*directory.UserSshPublicKey{}
-	sshCount := d.Get("ssh_public_keys.#").(int)
-	for i := 0; i < sshCount; i++ {
-		sshConfig := d.Get(fmt.Sprintf("ssh_public_keys.%d", i)).(map[string]interface{})
-		userSSH := &directory.UserSshPublicKey{}
-
-		if v, ok := sshConfig["expiration_time_usec"]; ok {
-			log.Printf("[DEBUG] Setting ssh %d expiration_time_usec: %v", i, int64(v.(int)))
-			userSSH.ExpirationTimeUsec = int64(v.(int))
-		}
-		if v, ok := sshConfig["key"]; ok {
-			log.Printf("[DEBUG] Setting ssh %d key: %s", i, v.(string))
-			userSSH.Key = v.(string)
-		}
-
-		userSSHs = append(userSSHs, userSSH)
-	}
-	user.SshPublicKeys = userSSHs
-
-	customSchemas := map[string]googleapi.RawMessage{}
-	for i := 0; i < d.Get("custom_schema.#").(int); i++ {
-		entry := d.Get(fmt.Sprintf("custom_schema.%d", i)).(map[string]interface{})
-		customSchemas[entry["name"].(string)] = []byte(entry["value"].(string))
-	}
-	if len(customSchemas) > 0 {
-		user.CustomSchemas = customSchemas
-	}
-
-	externalIDs := []*directory.UserExternalId{}
-	for i := 0; i < d.Get("external_ids.#").(int); i++ {
-		entry := d.Get(fmt.Sprintf("external_ids.%d", i)).(map[string]interface{})
-		externalID := &directory.UserExternalId{}
-		if v, ok := entry["custom_type"]; ok {
-			externalID.CustomType = v.(string)
-		}
-		if v, ok := entry["type"]; ok {
-			externalID.Type = v.(string)
-		}
-		if v, ok := entry["value"]; ok {
-			externalID.Value = v.(string)
-		}
-		externalIDs = append(externalIDs, externalID)
-	}
-	user.ExternalIds = externalIDs
 
-	organizations := []*directory.UserOrganization{}
-	for i := 0; i < d.Get("organizations.#").(int); i++ {
-		entry := d.Get(fmt.Sprintf("organizations.%d", i)).(map[string]interface{})
-		organization := &directory.UserOrganization{}
-		if v, ok := entry["cost_center"]; ok {
-			organization.CostCenter = v.(string)
-		}
-		if v, ok := entry["custom_type"]; ok {
-			organization.CustomType = v.(string)
-		}
-		if v, ok := entry["department"]; ok {
-			organization.Department = v.(string)
-		}
-		if v, ok := entry["description"]; ok {
-			organization.Description = v.(string)
-		}
-		if v, ok := entry["domain"]; ok {
-			organization.Domain = v.(string)
-		}
-		if v, ok := entry["full_time_equivalent"]; ok {
-			organization.FullTimeEquivalent = int64(v.(int))
-		}
-		if v, ok := entry["location"]; ok {
-			organization.Location = v.(string)
-		}
-		if v, ok := entry["name"]; ok {
-			organization.Name = v.(string)
-		}
-		if v, ok := entry["primary"]; ok {
-			organization.Primary = v.(bool)
-		}
-		if v, ok := entry["symbol"]; ok {
-			organization.Symbol = v.(string)
-		}
-		if v, ok := entry["title"]; ok {
-			organization.Title = v.(string)
-		}
-		if v, ok := entry["type"]; ok {
-			organization.Type = v.(string)
-		}
+	customSchemas := expandCustomSchemas(d)
+	if len(customSchemas) > 0 {
+		user.CustomSchemas = customSchemas
+	}
 
-		organizations = append(organizations, organization)
+	user.ExternalIds = expandUserExternalIds(d.Get("external_ids").([]interface{}))
+	user.Relations = expandUserRelations(d.Get("relations").([]interface{}))
+	user.Languages = expandUserLanguages(d.Get("languages").([]interface{}))
+	user.Keywords = expandUserKeywords(d.Get("keywords").([]interface{}))
+	user.Websites = expandUserWebsites(d.Get("websites").([]interface{}))
+	user.Ims = expandUserIms(d.Get("ims").([]interface{}))
+	if gender := expandUserGender(d.Get("gender").([]interface{})); gender != nil {
+		user.Gender = gender
 	}
-	user.Organizations = organizations
 
-	user.SshPublicKeys = userSSHs
+	user.Organizations = expandUserOrganizations(d.Get("organizations").([]interface{}))
+	user.Phones = expandUserPhones(d.Get("phones").(*schema.Set).List())
+	user.Addresses = expandUserAddresses(d.Get("addresses").(*schema.Set).List())
+	user.Emails = expandUserEmails(d.Get("emails").(*schema.Set).List())
+
+	user.SshPublicKeys = expandUserSSHPublicKeys(d.Get("ssh_public_keys").([]interface{}))
 
 	userNamePrefix := "name"
 	userName := &directory.UserName{
@@ -588,8 +1672,6 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	user.Name = userName
 
-	var err error
-
 	updateExisting := config.UpdateExisting
 	if v, ok := d.GetOk("update_existing"); ok {
 		updateExisting = v.(bool)
@@ -615,75 +1697,117 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 
 		if locatedUser != nil {
 			log.Printf("[INFO] found existing user %s", locatedUser.PrimaryEmail)
-
-			err = retry(func() error {
-				_, err = config.directory.Users.Update(locatedUser.Id, user).Do()
-				return err
-			}, config.TimeoutMinutes)
-
-			if err != nil {
-				return fmt.Errorf("[ERROR] Error updating existing user: %s", err)
-			}
-
-			err = userAliasesUpdate(config, locatedUser, aliases)
-
-			if err != nil {
-				return err
-			}
-
-			log.Printf("[INFO] Updated user: %s", user.PrimaryEmail)
-			d.SetId(locatedUser.Id)
-			return resourceUserRead(d, meta)
+			return userAdoptExisting(d, meta, locatedUser, user, aliases)
 		}
 	}
 
 	// Transmit password related state on account creation only.
 	if v, ok := d.GetOk("password"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "password", v.(string))
+		log.Printf("[DEBUG] Setting password")
 		user.Password = v.(string)
 	}
 
+	generatedPassword := ""
 	if user.Password == "" {
-		res, err := password.Generate(32, 4, 4, false, false)
+		res, err := generateUserPassword()
 		if err != nil {
 			return err
 		}
 		user.Password = res
 		log.Printf("[INFO] A random password was generated for the user")
+		if d.Get("generate_password").(bool) {
+			generatedPassword = res
+		}
 	}
 
-	if v, ok := d.GetOk("hash_function"); ok {
+	// A generated password is never hashed
+	if v, ok := d.GetOk("hash_function"); ok && d.Get("password").(string) != "" {
 		log.Printf("[DEBUG] Setting %s: %s", "hash_function", v.(string))
 		user.HashFunction = v.(string)
 	}
 
 	user.ChangePasswordAtNextLogin = true
+	if v, ok := d.GetOkExists("change_password_at_next_login"); ok {
+		user.ChangePasswordAtNextLogin = v.(bool)
+	}
+	d.Set("change_password_at_next_login", user.ChangePasswordAtNextLogin)
+	if !user.ChangePasswordAtNextLogin {
+		user.ForceSendFields = append(user.ForceSendFields, "ChangePasswordAtNextLogin")
+	}
 
 	var createdUser *directory.User
-	err = retry(func() error {
+	err = retryPassDuplicate(func() error {
 		createdUser, err = config.directory.Users.Insert(user).Do()
 		return err
 	}, config.TimeoutMinutes)
 
 	if err != nil {
+		// The user may not have been listed yet, it exists nonetheless
+		if updateExisting && isDuplicateError(err) {
+			log.Printf("[INFO] User %s already exists, adopting it and overwriting existing values", user.PrimaryEmail)
+
+			var existingUser *directory.User
+			err = retry(func() error {
+				existingUser, err = config.directory.Users.Get(user.PrimaryEmail).Do()
+				return err
+			}, config.TimeoutMinutes)
+
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error fetching existing user %s: %s", user.PrimaryEmail, err)
+			}
+
+			// Never reset the password of an existing user
+			if generatedPassword != "" {
+				log.Printf("[WARN] No password is generated for the existing user %s, generated_password stays empty", user.PrimaryEmail)
+			}
+			user.Password = ""
+			user.HashFunction = ""
+			user.ChangePasswordAtNextLogin = false
+			user.ForceSendFields = stringSliceDifference(user.ForceSendFields, []string{"ChangePasswordAtNextLogin"})
+
+			return userAdoptExisting(d, meta, existingUser, user, aliases)
+		}
 		return fmt.Errorf("[ERROR] Error creating user: %s", err)
 	}
+	d.Set("generated_password", generatedPassword)
 
 	// Try to read the user, retrying for 404's
-	err = retryNotFound(func() error {
-		user, err = config.directory.Users.Get(createdUser.Id).Do()
-		return err
-	}, config.TimeoutMinutes)
+	err = retryReadAfterWrite(func() error {
+		return retry(func() error {
+			user, err = config.directory.Users.Get(createdUser.Id).Do()
+			return err
+		}, config.TimeoutMinutes)
+	})
 
 	if err != nil {
 		return fmt.Errorf("[ERROR] Taking too long to create this user: %s", err)
 	}
 
-	if user.Suspended == false || user.SuspensionReason != "" {
-		log.Printf("[ERROR] Your newly created user has been automatically suspended by Google: %s", createdUser.PrimaryEmail)
+	suspended := d.Get("is_suspended").(bool)
+	if user.Suspended && !suspended {
+		log.Printf("[ERROR] Your newly created user has been automatically suspended by Google: %s (%s)", createdUser.PrimaryEmail, user.SuspensionReason)
 		log.Printf("[ERROR] Simply log in to the account, verify and accept the terms to unsuspend the account.")
 	}
 
+	// The suspension is applied once the user exists, so that it is not
+	// lost when the insert ignores it.
+	if suspended && !user.Suspended {
+		err = userSuspensionUpdate(config, createdUser.Id, true)
+		if err != nil {
+			d.SetId(createdUser.Id)
+			return err
+		}
+	}
+
+	// Likewise, users can only be archived once they exist.
+	if archived := d.Get("archived").(bool); archived && !user.Archived {
+		err = userArchivedUpdate(config, createdUser.Id, true)
+		if err != nil {
+			d.SetId(createdUser.Id)
+			return err
+		}
+	}
+
 	// Now set POSIX data, after the account has been created.
 	err = userPosixCreate(d, createdUser.Id, meta)
 
@@ -692,7 +1816,7 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 		log.Printf("[ERROR] Not failing on this operation, your POSIX data has not been set. A next apply will retry.")
 	}
 
-	err = userAliasesUpdate(config, createdUser, aliases)
+	err = userAliasesUpdate(config, createdUser, nil, aliases)
 
 	if err != nil {
 		return err
@@ -703,10 +1827,64 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 	return resourceUserRead(d, meta)
 }
 
-func userAliasesUpdate(config *Config, user *directory.User, aliases []string) error {
+// userAdoptExisting updates an existing user to match the configuration and
+// takes over its management.
+func userAdoptExisting(d *schema.ResourceData, meta interface{}, existingUser *directory.User, user *directory.User, aliases []string) error {
+	config, err := impersonatedConfig(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		_, err = config.directory.Users.Update(existingUser.Id, user).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating existing user: %s", err)
+	}
+
+	// The configured aliases the user already has are taken over
+	adopted := stringSliceDifference(aliases, stringSliceDifference(aliases, existingUser.Aliases))
+	err = userAliasesUpdate(config, existingUser, adopted, aliases)
+
+	if err != nil {
+		return err
+	}
+
+	if suspended := d.Get("is_suspended").(bool); suspended != existingUser.Suspended {
+		err = userSuspensionUpdate(config, existingUser.Id, suspended)
+		if err != nil {
+			return err
+		}
+	}
+
+	if archived := d.Get("archived").(bool); archived != existingUser.Archived {
+		err = userArchivedUpdate(config, existingUser.Id, archived)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[INFO] Updated user: %s", user.PrimaryEmail)
+	d.SetId(existingUser.Id)
+	return resourceUserRead(d, meta)
+}
+
+// userAliasesUpdate adds the aliases which are new in the configuration and
+// deletes the ones which were removed from it. Other aliases of the user are
+// left alone, they belong to gsuite_user_alias resources.
+func userAliasesUpdate(config *Config, user *directory.User, oldAliases []string, newAliases []string) error {
+	createdAliases := stringSliceDifference(newAliases, oldAliases)
+	deletedAliases := stringSliceDifference(oldAliases, newAliases)
+
+	// An alias the user already has is managed by gsuite_user_alias
+	if conflicts := stringSliceDifference(createdAliases, stringSliceDifference(createdAliases, user.Aliases)); len(conflicts) > 0 {
+		return fmt.Errorf("[ERROR] Aliases %s of user %s already exist, they can't be managed by both aliases and gsuite_user_alias", strings.Join(conflicts, ", "), user.PrimaryEmail)
+	}
 
-	createdAliases := stringSliceDifference(aliases, user.Aliases)
-	deletedAliases := stringSliceDifference(user.Aliases, aliases)
+	// Aliases which are already gone don't need to be deleted
+	deletedAliases = stringSliceDifference(deletedAliases, stringSliceDifference(deletedAliases, user.Aliases))
 
 	for _, alias := range createdAliases {
 		err := retry(func() error {
@@ -732,53 +1910,105 @@ func userAliasesUpdate(config *Config, user *directory.User, aliases []string) e
 	return nil
 }
 
-func userPosixCreate(d *schema.ResourceData, userID string, meta interface{}) error {
-	config := meta.(*Config)
+// userSuspensionUpdate suspends or unsuspends a user with a patch, leaving all
+// other fields of the user untouched.
+func userSuspensionUpdate(config *Config, userID string, suspended bool) error {
+	log.Printf("[DEBUG] Updating user is_suspended: %t", suspended)
 
-	user := &directory.User{}
+	user := &directory.User{
+		Suspended:       suspended,
+		ForceSendFields: []string{"Suspended"},
+	}
+
+	err := retry(func() error {
+		_, err := config.directory.Users.Patch(userID, user).Do()
+		return err
+	}, config.TimeoutMinutes)
 
-	userPosixs := []*directory.UserPosixAccount{}
-	posixCount := d.Get("posix_accounts.#").(int)
-	for i := 0; i < posixCount; i++ {
-		posixConfig := d.Get(fmt.Sprintf("posix_accounts.%d", i)).(map[string]interface{})
-		userPosix := &directory.UserPosixAccount{}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating user is_suspended: %s", err)
+	}
 
-		if posixConfig["gecos"] != "" {
-			log.Printf("[DEBUG] Setting posix %d gecos: %s", i, posixConfig["gecos"].(string))
-			userPosix.Gecos = posixConfig["gecos"].(string)
-		}
-		if posixConfig["gid"] != 0 {
-			log.Printf("[DEBUG] Setting posix %d gid: %d", i, uint64(posixConfig["gid"].(int)))
-			userPosix.Gid = uint64(posixConfig["gid"].(int))
-		}
-		if posixConfig["home_directory"] != "" {
-			log.Printf("[DEBUG] Setting posix %d home_directory: %s", i, posixConfig["home_directory"].(string))
-			userPosix.HomeDirectory = posixConfig["home_directory"].(string)
-		}
-		if posixConfig["system_id"] != "" {
-			log.Printf("[DEBUG] Setting posix %d system_id: %s", i, posixConfig["system_id"].(string))
-			userPosix.SystemId = posixConfig["system_id"].(string)
-		}
-		if posixConfig["shell"] != "" {
-			log.Printf("[DEBUG] Setting posix %d shell: %s", i, posixConfig["shell"].(string))
-			userPosix.Shell = posixConfig["shell"].(string)
-		}
-		if posixConfig["primary"] != "" {
-			log.Printf("[DEBUG] Setting posix %d primary: %t", i, posixConfig["primary"].(bool))
-			userPosix.Primary = posixConfig["primary"].(bool)
-		}
-		if posixConfig["uid"] != 0 {
-			log.Printf("[DEBUG] Setting posix %d uid: %d", i, uint64(posixConfig["uid"].(int)))
-			userPosix.Uid = uint64(posixConfig["uid"].(int))
-		}
-		if posixConfig["username"] != "" {
-			log.Printf("[DEBUG] Setting posix %d username: %s", i, posixConfig["username"].(string))
-			userPosix.Username = posixConfig["username"].(string)
-		}
+	return nil
+}
+
+// userIncludeInGlobalAddressList combines include_in_global_address_list and
+// the deprecated include_in_global_list, setting either to false hides the user.
+func userIncludeInGlobalAddressList(d *schema.ResourceData) bool {
+	return d.Get("include_in_global_address_list").(bool) && d.Get("include_in_global_list").(bool)
+}
+
+// includeInGlobalAddressListDiffSuppress hides the diff of the argument which
+// isn't configured when the other one already matches the user.
+func includeInGlobalAddressListDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return old == strconv.FormatBool(userIncludeInGlobalAddressList(d))
+}
+
+// setUserIncludeInGlobalAddressList always sends the global address list
+// visibility, false would otherwise be dropped as the zero value.
+func setUserIncludeInGlobalAddressList(user *directory.User, include bool) {
+	user.IncludeInGlobalAddressList = include
+	user.ForceSendFields = append(user.ForceSendFields, "IncludeInGlobalAddressList")
+}
+
+// userOrgUnitUpdate moves a user to another org unit with a patch, leaving all
+// other fields of the user untouched. The policies and licenses that apply to
+// the user follow the org unit, so the move is logged as well.
+func userOrgUnitUpdate(config *Config, userID, from, to string) error {
+	to = normalizeOrgUnitPath(to)
+	if to == "" {
+		to = "/"
+	}
+	log.Printf("[DEBUG] Updating user org_unit_path: %s", to)
+
+	user := &directory.User{
+		OrgUnitPath: to,
+	}
+
+	err := retry(func() error {
+		_, err := config.directory.Users.Patch(userID, user).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating user org_unit_path: %s", err)
+	}
+
+	log.Printf("[INFO] Moved user %s from org unit %q to %q, the policies and licenses that apply to the user may change with it", userID, from, to)
+	return nil
+}
+
+// userArchivedUpdate archives or unarchives a user with a patch, leaving all
+// other fields of the user untouched.
+func userArchivedUpdate(config *Config, userID string, archived bool) error {
+	log.Printf("[DEBUG] Updating user archived: %t", archived)
+
+	user := &directory.User{
+		Archived:        archived,
+		ForceSendFields: []string{"Archived"},
+	}
+
+	err := retry(func() error {
+		_, err := config.directory.Users.Patch(userID, user).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating user archived: %s", err)
+	}
+
+	return nil
+}
 
-		userPosixs = append(userPosixs, userPosix)
+func userPosixCreate(d *schema.ResourceData, userID string, meta interface{}) error {
+	config, err := impersonatedConfig(d, meta)
+	if err != nil {
+		return err
 	}
-	user.PosixAccounts = userPosixs
+
+	user := &directory.User{}
+
+	user.PosixAccounts = expandUserPosixAccounts(d.Get("posix_accounts").([]interface{}))
 
 	userNamePrefix := "name"
 	userName := &directory.UserName{
@@ -787,7 +2017,6 @@ func userPosixCreate(d *schema.ResourceData, userID string, meta interface{}) er
 	}
 	user.Name = userName
 
-	var err error
 	err = retry(func() error {
 		_, err = config.directory.Users.Update(userID, user).Do()
 		if e, ok := err.(*googleapi.Error); ok {
@@ -803,11 +2032,39 @@ func userPosixCreate(d *schema.ResourceData, userID string, meta interface{}) er
 	return nil
 }
 
+// setUserPasswordPatch sets the password fields of a user update to the ones
+// that changed. The password is only sent when it changed, it can't be read
+// back and sending it again would reset it, e.g. when only
+// change_password_at_next_login is flipped. The latter only has a diff when it
+// is configured to a different value.
+func setUserPasswordPatch(d *schema.ResourceData, user *directory.User) {
+	if d.HasChange("password") {
+		if v, ok := d.GetOk("password"); ok {
+			log.Printf("[DEBUG] Updating user password")
+			user.Password = v.(string)
+			user.HashFunction = d.Get("hash_function").(string)
+		}
+	} else if d.HasChange("hash_function") {
+		log.Printf("[WARN] The hash_function of a user only applies to a new password, change the password to update it")
+	}
+
+	if d.HasChange("change_password_at_next_login") {
+		log.Printf("[DEBUG] Updating user change_password_at_next_login: %t", d.Get("change_password_at_next_login").(bool))
+		user.ChangePasswordAtNextLogin = d.Get("change_password_at_next_login").(bool)
+		user.ForceSendFields = append(user.ForceSendFields, "ChangePasswordAtNextLogin")
+	}
+}
+
 func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
+	config, err := impersonatedConfig(d, meta)
+	if err != nil {
+		return err
+	}
 
 	user := &directory.User{}
-	nullFields := []string{"change_password_next_login", "hash_function", "password"}
+	nullFields := []string{}
+
+	setUserPasswordPatch(d, user)
 
 	if d.HasChange("deletion_time") {
 		if v, ok := d.GetOk("deletion_time"); ok {
@@ -833,11 +2090,11 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 	if d.HasChange("recovery_email") {
 		if v, ok := d.GetOk("recovery_email"); ok {
 			log.Printf("[DEBUG] Updating user recovery_email: %s", d.Get("recovery_email").(string))
-			user.RecoveryEmail = v.(string)
+			user.RecoveryEmail = strings.ToLower(v.(string))
 		} else {
 			log.Printf("[DEBUG] Removing user recovery_email")
 			user.RecoveryEmail = ""
-			nullFields = append(nullFields, "recovery_email")
+			nullFields = append(nullFields, "RecoveryEmail")
 		}
 	}
 
@@ -848,201 +2105,82 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 		} else {
 			log.Printf("[DEBUG] Removing user recovery_phone")
 			user.RecoveryPhone = ""
-			nullFields = append(nullFields, "recovery_phone")
+			nullFields = append(nullFields, "RecoveryPhone")
 		}
 	}
 
-	if d.HasChange("org_unit_path") {
-		if v, ok := d.GetOk("org_unit_path"); ok {
-			log.Printf("[DEBUG] Updating user org_unit_path: %s", d.Get("org_unit_path").(string))
-			user.OrgUnitPath = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing user org_unit_path")
-			user.OrgUnitPath = ""
-			nullFields = append(nullFields, "org_unit_path")
-		}
+	if d.HasChange("include_in_global_address_list") || d.HasChange("include_in_global_list") {
+		log.Printf("[DEBUG] Updating user include_in_global_address_list: %t", userIncludeInGlobalAddressList(d))
+		setUserIncludeInGlobalAddressList(user, userIncludeInGlobalAddressList(d))
+	}
+	// Changed booleans are always sent, false would otherwise be dropped as the
+	// zero value
+	if d.HasChange("is_ip_whitelisted") {
+		log.Printf("[DEBUG] Updating user is_ip_whitelisted: %t", d.Get("is_ip_whitelisted").(bool))
+		user.IpWhitelisted = d.Get("is_ip_whitelisted").(bool)
+		user.ForceSendFields = append(user.ForceSendFields, "IpWhitelisted")
 	}
 
-	if d.HasChange("suspension_reason") {
-		if v, ok := d.GetOk("suspension_reason"); ok {
-			log.Printf("[DEBUG] Updating user suspension_reason: %s", d.Get("suspension_reason").(string))
-			user.SuspensionReason = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing user suspension_reason")
-			user.SuspensionReason = ""
-			nullFields = append(nullFields, "suspension_reason")
-		}
+	if d.HasChange("ssh_public_keys") {
+		user.SshPublicKeys = expandUserSSHPublicKeys(d.Get("ssh_public_keys").([]interface{}))
 	}
 
-	if d.HasChange("include_in_global_list") {
-		if v, ok := d.GetOk("include_in_global_list"); ok {
-			log.Printf("[DEBUG] Updating user include_in_global_list: %t", d.Get("include_in_global_list").(bool))
-			user.IncludeInGlobalAddressList = v.(bool)
-		} else {
-			log.Printf("[DEBUG] Removing user include_in_global_list")
-			user.IncludeInGlobalAddressList = true
-			nullFields = append(nullFields, "include_in_global_list")
-		}
+	if d.HasChange("posix_accounts") {
+		user.PosixAccounts = expandUserPosixAccounts(d.Get("posix_accounts").([]interface{}))
 	}
-	if d.HasChange("is_ip_whitelisted") {
-		if v, ok := d.GetOk("is_ip_whitelisted"); ok {
-			log.Printf("[DEBUG] Updating user is_ip_whitelisted: %t", d.Get("is_ip_whitelisted").(bool))
-			user.IpWhitelisted = v.(bool)
-		} else {
-			log.Printf("[DEBUG] Removing user is_ip_whitelisted")
-			user.IpWhitelisted = false
-			nullFields = append(nullFields, "is_ip_whitelisted")
-		}
+
+	if d.HasChange("custom_schema") {
+		user.CustomSchemas = expandCustomSchemas(d)
 	}
-	if d.HasChange("is_suspended") {
-		if v, ok := d.GetOk("is_suspended"); ok {
-			log.Printf("[DEBUG] Updating user is_suspended: %t", d.Get("is_suspended").(bool))
-			user.Suspended = v.(bool)
-		} else {
-			log.Printf("[DEBUG] Removing user is_suspended")
-			user.Suspended = false
-			nullFields = append(nullFields, "is_suspended")
-		}
+
+	if d.HasChange("external_ids") {
+		user.ExternalIds = expandUserExternalIds(d.Get("external_ids").([]interface{}))
 	}
 
-	if d.HasChange("ssh_public_keys") {
-		userSSHs := []*directory.UserSshPublicKey{}
-		sshCount := d.Get("ssh_public_keys.#").(int)
-		for i := 0; i < sshCount; i++ {
-			sshConfig := d.Get(fmt.Sprintf("ssh_public_keys.%d", i)).(map[string]interface{})
-			userSSH := &directory.UserSshPublicKey{}
-
-			if v, ok := sshConfig["expiration_time_usec"]; ok {
-				log.Printf("[DEBUG] Setting ssh %d expiration_time_usec: %v", i, int64(v.(int)))
-				userSSH.ExpirationTimeUsec = int64(v.(int))
-			}
-			if v, ok := sshConfig["key"]; ok {
-				log.Printf("[DEBUG] Setting ssh %d key: %s", i, v.(string))
-				userSSH.Key = v.(string)
-			}
+	if d.HasChange("relations") {
+		user.Relations = expandUserRelations(d.Get("relations").([]interface{}))
+	}
 
-			userSSHs = append(userSSHs, userSSH)
-		}
-		user.SshPublicKeys = userSSHs
+	if d.HasChange("languages") {
+		user.Languages = expandUserLanguages(d.Get("languages").([]interface{}))
 	}
 
-	if d.HasChange("posix_accounts") {
-		userPosixs := []*directory.UserPosixAccount{}
-		posixCount := d.Get("posix_accounts.#").(int)
-		for i := 0; i < posixCount; i++ {
-			posixConfig := d.Get(fmt.Sprintf("posix_accounts.%d", i)).(map[string]interface{})
-			userPosix := &directory.UserPosixAccount{}
-
-			if posixConfig["gecos"] != "" {
-				log.Printf("[DEBUG] Setting posix %d gecos: %s", i, posixConfig["gecos"].(string))
-				userPosix.Gecos = posixConfig["gecos"].(string)
-			}
-			if posixConfig["gid"] != 0 {
-				log.Printf("[DEBUG] Setting posix %d gid: %d", i, uint64(posixConfig["gid"].(int)))
-				userPosix.Gid = uint64(posixConfig["gid"].(int))
-			}
-			if posixConfig["home_directory"] != "" {
-				log.Printf("[DEBUG] Setting posix %d home_directory: %s", i, posixConfig["home_directory"].(string))
-				userPosix.HomeDirectory = posixConfig["home_directory"].(string)
-			}
-			if posixConfig["system_id"] != "" {
-				log.Printf("[DEBUG] Setting posix %d system_id: %s", i, posixConfig["system_id"].(string))
-				userPosix.SystemId = posixConfig["system_id"].(string)
-			}
-			if posixConfig["shell"] != "" {
-				log.Printf("[DEBUG] Setting posix %d shell: %s", i, posixConfig["shell"].(string))
-				userPosix.Shell = posixConfig["shell"].(string)
-			}
-			if posixConfig["primary"] != "" {
-				log.Printf("[DEBUG] Setting posix %d primary: %t", i, posixConfig["primary"].(bool))
-				userPosix.Primary = posixConfig["primary"].(bool)
-			}
-			if posixConfig["uid"] != 0 {
-				log.Printf("[DEBUG] Setting posix %d uid: %d", i, uint64(posixConfig["uid"].(int)))
-				userPosix.Uid = uint64(posixConfig["uid"].(int))
-			}
-			if posixConfig["username"] != "" {
-				log.Printf("[DEBUG] Setting posix %d username: %s", i, posixConfig["username"].(string))
-				userPosix.Username = posixConfig["username"].(string)
-			}
+	if d.HasChange("keywords") {
+		user.Keywords = expandUserKeywords(d.Get("keywords").([]interface{}))
+	}
 
-			userPosixs = append(userPosixs, userPosix)
-		}
-		user.PosixAccounts = userPosixs
+	if d.HasChange("websites") {
+		user.Websites = expandUserWebsites(d.Get("websites").([]interface{}))
 	}
 
-	if d.HasChange("custom_schema") {
-		customSchemas := map[string]googleapi.RawMessage{}
-		for i := 0; i < d.Get("custom_schema.#").(int); i++ {
-			entry := d.Get(fmt.Sprintf("custom_schema.%d", i)).(map[string]interface{})
-			customSchemas[entry["name"].(string)] = []byte(entry["value"].(string))
-		}
-		user.CustomSchemas = customSchemas
+	if d.HasChange("ims") {
+		user.Ims = expandUserIms(d.Get("ims").([]interface{}))
 	}
 
-	if d.HasChange("external_ids") {
-		externalIDs := []*directory.UserExternalId{}
-		for i := 0; i < d.Get("external_ids.#").(int); i++ {
-			entry := d.Get(fmt.Sprintf("external_ids.%d", i)).(map[string]interface{})
-			externalID := &directory.UserExternalId{}
-			if v, ok := entry["custom_type"]; ok {
-				externalID.CustomType = v.(string)
-			}
-			if v, ok := entry["type"]; ok {
-				externalID.Type = v.(string)
-			}
-			if v, ok := entry["value"]; ok {
-				externalID.Value = v.(string)
-			}
-			externalIDs = append(externalIDs, externalID)
+	if d.HasChange("gender") {
+		if gender := expandUserGender(d.Get("gender").([]interface{})); gender != nil {
+			log.Printf("[DEBUG] Updating user gender: %s", gender.Type)
+			user.Gender = gender
+		} else {
+			log.Printf("[DEBUG] Removing user gender")
+			nullFields = append(nullFields, "Gender")
 		}
-		user.ExternalIds = externalIDs
 	}
 
 	if d.HasChange("organizations") {
-		organizations := []*directory.UserOrganization{}
-		for i := 0; i < d.Get("organizations.#").(int); i++ {
-			entry := d.Get(fmt.Sprintf("organizations.%d", i)).(map[string]interface{})
-			organization := &directory.UserOrganization{}
-			if v, ok := entry["cost_center"]; ok {
-				organization.CostCenter = v.(string)
-			}
-			if v, ok := entry["custom_type"]; ok {
-				organization.CustomType = v.(string)
-			}
-			if v, ok := entry["department"]; ok {
-				organization.Department = v.(string)
-			}
-			if v, ok := entry["description"]; ok {
-				organization.Description = v.(string)
-			}
-			if v, ok := entry["domain"]; ok {
-				organization.Domain = v.(string)
-			}
-			if v, ok := entry["full_time_equivalent"]; ok {
-				organization.FullTimeEquivalent = int64(v.(int))
-			}
-			if v, ok := entry["location"]; ok {
-				organization.Location = v.(string)
-			}
-			if v, ok := entry["name"]; ok {
-				organization.Name = v.(string)
-			}
-			if v, ok := entry["primary"]; ok {
-				organization.Primary = v.(bool)
-			}
-			if v, ok := entry["symbol"]; ok {
-				organization.Symbol = v.(string)
-			}
-			if v, ok := entry["title"]; ok {
-				organization.Title = v.(string)
-			}
-			if v, ok := entry["type"]; ok {
-				organization.Type = v.(string)
-			}
-			organizations = append(organizations, organization)
-		}
-		user.Organizations = organizations
+		user.Organizations = expandUserOrganizations(d.Get("organizations").([]interface{}))
+	}
+
+	if d.HasChange("phones") {
+		user.Phones = expandUserPhones(d.Get("phones").(*schema.Set).List())
+	}
+
+	if d.HasChange("addresses") {
+		user.Addresses = expandUserAddresses(d.Get("addresses").(*schema.Set).List())
+	}
+
+	if d.HasChange("emails") {
+		user.Emails = expandUserEmails(d.Get("emails").(*schema.Set).List())
 	}
 
 	userNamePrefix := "name"
@@ -1057,7 +2195,6 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	var updatedUser *directory.User
-	var err error
 	err = retry(func() error {
 		updatedUser, err = config.directory.Users.Update(d.Id(), user).Do()
 		if e, ok := err.(*googleapi.Error); ok {
@@ -1071,16 +2208,40 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("[ERROR] Error updating user: %s", err)
 	}
 
-	if d.HasChange("aliases") {
+	if d.HasChange("is_suspended") {
+		err = userSuspensionUpdate(config, d.Id(), d.Get("is_suspended").(bool))
+		if err != nil {
+			return err
+		}
+	}
 
-		aliases := []string{}
-		if v, ok := d.GetOk("aliases"); ok {
-			for _, alias := range v.(*schema.Set).List() {
-				aliases = append(aliases, alias.(string))
-			}
+	if d.HasChange("archived") {
+		err = userArchivedUpdate(config, d.Id(), d.Get("archived").(bool))
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("org_unit_path") {
+		from, to := d.GetChange("org_unit_path")
+		err = userOrgUnitUpdate(config, d.Id(), from.(string), to.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("aliases") {
+		o, n := d.GetChange("aliases")
+		oldAliases := []string{}
+		for _, alias := range o.(*schema.Set).List() {
+			oldAliases = append(oldAliases, strings.ToLower(alias.(string)))
+		}
+		newAliases := []string{}
+		for _, alias := range n.(*schema.Set).List() {
+			newAliases = append(newAliases, strings.ToLower(alias.(string)))
 		}
 
-		err = userAliasesUpdate(config, updatedUser, aliases)
+		err = userAliasesUpdate(config, updatedUser, oldAliases, newAliases)
 		if err != nil {
 			return err
 		}
@@ -1091,12 +2252,14 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
+	config, err := impersonatedConfig(d, meta)
+	if err != nil {
+		return err
+	}
 
 	var user *directory.User
-	var err error
 	err = retry(func() error {
-		user, err = config.directory.Users.Get(d.Id()).Projection("full").Do()
+		user, err = getUser(d, config, d.Id()).Do()
 		if user != nil && user.Name == nil {
 			return errors.New("Eventual consistency. Please try again")
 		}
@@ -1114,14 +2277,19 @@ func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("recovery_phone", user.RecoveryPhone)
 	d.Set("org_unit_path", user.OrgUnitPath)
 	d.Set("suspension_reason", user.SuspensionReason)
+	d.Set("include_in_global_address_list", user.IncludeInGlobalAddressList)
 	d.Set("include_in_global_list", user.IncludeInGlobalAddressList)
 	d.Set("is_ip_whitelisted", user.IpWhitelisted)
 	d.Set("is_admin", user.IsAdmin)
 	d.Set("is_delegated_admin", user.IsDelegatedAdmin)
 	d.Set("is_suspended", user.Suspended)
+	d.Set("archived", user.Archived)
 	d.Set("2s_enrolled", user.IsEnrolledIn2Sv)
 	d.Set("2s_enforced", user.IsEnforcedIn2Sv)
-	d.Set("aliases", user.Aliases)
+	d.Set("ip_whitelisted", user.IpWhitelisted)
+	d.Set("is_enforced_in_2sv", user.IsEnforcedIn2Sv)
+	d.Set("is_enrolled_in_2sv", user.IsEnrolledIn2Sv)
+	d.Set("aliases", flattenUserAliases(d, user))
 	d.Set("agreed_to_terms", user.AgreedToTerms)
 	d.Set("creation_time", user.CreationTime)
 	d.Set("customer_id", user.CustomerId)
@@ -1129,10 +2297,10 @@ func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("last_login_time", user.LastLoginTime)
 	d.Set("is_mailbox_setup", user.IsMailboxSetup)
 	d.Set("name", flattenUserName(user.Name))
-	d.Set("posix_accounts", user.PosixAccounts)
-	d.Set("ssh_public_keys", user.SshPublicKeys)
-	d.Set("external_ids", user.ExternalIds)
-	d.Set("organizations", user.Organizations)
+
+	if err = flattenUserBlocks(d, user); err != nil {
+		return err
+	}
 
 	err, flattenedCustomSchema := flattenCustomSchema(user.CustomSchemas)
 	if err != nil {
@@ -1147,9 +2315,11 @@ func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceUserDelete(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
+	config, err := impersonatedConfig(d, meta)
+	if err != nil {
+		return err
+	}
 
-	var err error
 	err = retry(func() error {
 		err = config.directory.Users.Delete(d.Id()).Do()
 		return err
@@ -1166,7 +2336,9 @@ func resourceUserDelete(d *schema.ResourceData, meta interface{}) error {
 func resourceUserImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	config := meta.(*Config)
 
-	id, err := config.directory.Users.Get(d.Id()).Projection("full").Do()
+	// Custom schemas and admin-only fields are only returned in the full
+	// admin view, without them the import ends up with a diff
+	id, err := config.directory.Users.Get(d.Id()).Projection("full").ViewType("admin_view").Do()
 
 	if err != nil {
 		return nil, fmt.Errorf("Error fetching user. Make sure the user exists: %s ", err)
@@ -1179,13 +2351,18 @@ func resourceUserImporter(d *schema.ResourceData, meta interface{}) ([]*schema.R
 	d.Set("recovery_phone", id.RecoveryPhone)
 	d.Set("org_unit_path", id.OrgUnitPath)
 	d.Set("suspension_reason", id.SuspensionReason)
+	d.Set("include_in_global_address_list", id.IncludeInGlobalAddressList)
 	d.Set("include_in_global_list", id.IncludeInGlobalAddressList)
 	d.Set("is_ip_whitelisted", id.IpWhitelisted)
 	d.Set("is_admin", id.IsAdmin)
 	d.Set("is_delegated_admin", id.IsDelegatedAdmin)
 	d.Set("is_suspended", id.Suspended)
+	d.Set("archived", id.Archived)
 	d.Set("2s_enrolled", id.IsEnrolledIn2Sv)
 	d.Set("2s_enforced", id.IsEnforcedIn2Sv)
+	d.Set("ip_whitelisted", id.IpWhitelisted)
+	d.Set("is_enforced_in_2sv", id.IsEnforcedIn2Sv)
+	d.Set("is_enrolled_in_2sv", id.IsEnrolledIn2Sv)
 	d.Set("aliases", id.Aliases)
 	d.Set("agreed_to_terms", id.AgreedToTerms)
 	d.Set("creation_time", id.CreationTime)
@@ -1194,11 +2371,15 @@ func resourceUserImporter(d *schema.ResourceData, meta interface{}) ([]*schema.R
 	d.Set("last_login_time", id.LastLoginTime)
 	d.Set("is_mailbox_setup", id.IsMailboxSetup)
 
+	// Only sent on create or change, assume the default so that importing does
+	// not force a password change
+	d.Set("change_password_at_next_login", true)
+
 	d.Set("name", flattenUserName(id.Name))
-	d.Set("posix_accounts", id.PosixAccounts)
-	d.Set("ssh_public_keys", id.SshPublicKeys)
-	d.Set("external_ids", id.ExternalIds)
-	d.Set("organizations", id.Organizations)
+
+	if err = flattenUserBlocks(d, id); err != nil {
+		return nil, err
+	}
 
 	err, flattenedCustomSchema := flattenCustomSchema(id.CustomSchemas)
 	if err != nil {
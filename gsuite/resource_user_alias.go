@@ -0,0 +1,141 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceUserAlias() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserAliasCreate,
+		Read:   resourceUserAliasRead,
+		Delete: resourceUserAliasDelete,
+		// Aliases can not be renamed, there is no update method
+		Importer: &schema.ResourceImporter{
+			State: resourceUserAliasImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
+			},
+
+			"alias": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
+			},
+		},
+	}
+}
+
+func resourceUserAliasCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	primaryEmail := strings.ToLower(d.Get("primary_email").(string))
+	alias := &directory.Alias{
+		Alias: strings.ToLower(d.Get("alias").(string)),
+	}
+
+	var createdAlias *directory.Alias
+	var err error
+	err = retry(func() error {
+		createdAlias, err = config.directory.Users.Aliases.Insert(primaryEmail, alias).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating user alias: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", primaryEmail, createdAlias.Alias))
+	log.Printf("[INFO] Created user alias: %s", createdAlias.Alias)
+	return resourceUserAliasRead(d, meta)
+}
+
+func resourceUserAliasRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	primaryEmail := strings.ToLower(d.Get("primary_email").(string))
+	alias := strings.ToLower(d.Get("alias").(string))
+
+	var aliasesResponse *directory.Aliases
+	var err error
+	err = retry(func() error {
+		aliasesResponse, err = config.directory.Users.Aliases.List(primaryEmail).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	// A 404 means the owning user has been deleted, together with its aliases
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("User alias %q", alias))
+	}
+
+	for _, v := range aliasesResponse.Aliases {
+		if c, ok := v.(map[string]interface{}); ok && strings.ToLower(c["alias"].(string)) == alias {
+			d.Set("primary_email", primaryEmail)
+			d.Set("alias", alias)
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] Removing user alias %q because it's gone", alias)
+	d.SetId("")
+	return nil
+}
+
+func resourceUserAliasDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	primaryEmail := strings.ToLower(d.Get("primary_email").(string))
+	alias := strings.ToLower(d.Get("alias").(string))
+
+	var err error
+	err = retry(func() error {
+		err = config.directory.Users.Aliases.Delete(primaryEmail, alias).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] User alias %q is already gone", alias)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error deleting user alias: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using [primary email]{:,/}[alias]
+func resourceUserAliasImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), ":")
+	if len(s) < 2 {
+		s = strings.Split(d.Id(), "/")
+	}
+
+	if len(s) < 2 {
+		return nil, fmt.Errorf("[WARN] Import via [primary email]:[alias] or [primary email]/[alias]")
+	}
+	primaryEmail, alias := strings.ToLower(s[0]), strings.ToLower(s[1])
+
+	d.SetId(fmt.Sprintf("%s/%s", primaryEmail, alias))
+	d.Set("primary_email", primaryEmail)
+	d.Set("alias", alias)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,72 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Revoking an application-specific password of a user is a one-off action,
+// destroying the resource does not restore the password.
+func resourceUserAspRevocation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserAspRevocationCreate,
+		Read:   resourceUserAspRevocationRead,
+		Delete: resourceUserAspRevocationDelete,
+
+		Schema: map[string]*schema.Schema{
+			// The user, by email, alias or id
+			"user_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"code_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceUserAspRevocationCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userKey := strings.ToLower(d.Get("user_key").(string))
+	codeID := int64(d.Get("code_id").(int))
+
+	var err error
+	err = retry(func() error {
+		err = config.directory.Asps.Delete(userKey, codeID).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	// A password which is already gone is revoked just as well
+	if isNotFound(err) {
+		log.Printf("[WARN] Application-specific password %d of user %s is already revoked", codeID, userKey)
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error revoking application-specific password %d of user %s: %s", codeID, userKey, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", userKey, codeID))
+	log.Printf("[INFO] Revoked application-specific password %d of user %s", codeID, userKey)
+	return nil
+}
+
+// Application-specific passwords get a new code ID when created again, so a
+// revoked password never comes back.
+func resourceUserAspRevocationRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceUserAspRevocationDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] The revocation of application-specific password %s can't be undone, removing it from state only", d.Id())
+	d.SetId("")
+	return nil
+}
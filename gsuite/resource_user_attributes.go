@@ -22,13 +22,12 @@ func resourceUserAttributes() *schema.Resource {
 			State: resourceUserAttributesImporter,
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: mergeSchemas(map[string]*schema.Schema{
 			"primary_email": {
-				Type:     schema.TypeString,
-				Required: true,
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
 			},
 
 			"custom_schema": {
@@ -47,7 +46,7 @@ func resourceUserAttributes() *schema.Resource {
 					},
 				},
 			},
-		},
+		}, schemaUserRead),
 	}
 }
 
@@ -111,7 +110,7 @@ func resourceUserAttributesRead(d *schema.ResourceData, meta interface{}) error
 	var user *directory.User
 	var err error
 	err = retry(func() error {
-		user, err = config.directory.Users.Get(d.Id()).Projection("full").Do()
+		user, err = getUser(d, config, d.Id()).Do()
 		if user != nil && user.Name == nil {
 			return errors.New("Eventual consistency. Please try again")
 		}
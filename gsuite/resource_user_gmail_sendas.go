@@ -0,0 +1,274 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	gmail "google.golang.org/api/gmail/v1"
+)
+
+func resourceUserGmailSendAs() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserGmailSendAsCreate,
+		Read:   resourceUserGmailSendAsRead,
+		Update: resourceUserGmailSendAsUpdate,
+		Delete: resourceUserGmailSendAsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceUserGmailSendAsImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
+			},
+
+			"send_as_email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
+			},
+
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"reply_to": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"signature": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Only one address can be the default, the API therefore only allows
+			// setting this to true
+			"is_default": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"treat_as_alias": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"is_primary": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"verification_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceUserGmailSendAsCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userEmail := strings.ToLower(d.Get("user_email").(string))
+	gmailService, err := config.gmailService(userEmail)
+	if err != nil {
+		return err
+	}
+
+	sendAs := &gmail.SendAs{
+		SendAsEmail:     strings.ToLower(d.Get("send_as_email").(string)),
+		DisplayName:     d.Get("display_name").(string),
+		ReplyToAddress:  d.Get("reply_to").(string),
+		Signature:       d.Get("signature").(string),
+		TreatAsAlias:    d.Get("treat_as_alias").(bool),
+		ForceSendFields: []string{"TreatAsAlias"},
+	}
+
+	var created *gmail.SendAs
+	err = retry(func() error {
+		created, err = gmailService.Users.Settings.SendAs.Create(userEmail, sendAs).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating send-as address: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", userEmail, created.SendAsEmail))
+	log.Printf("[INFO] Created send-as address %s for %s", created.SendAsEmail, userEmail)
+
+	if created.VerificationStatus == "pending" {
+		log.Printf("[WARN] Send-as address %s is pending verification", created.SendAsEmail)
+	}
+
+	if d.Get("is_default").(bool) {
+		if err := setUserGmailSendAsDefault(config, gmailService, userEmail, created.SendAsEmail); err != nil {
+			return err
+		}
+	}
+
+	return resourceUserGmailSendAsRead(d, meta)
+}
+
+func setUserGmailSendAsDefault(config *Config, gmailService *gmail.Service, userEmail, sendAsEmail string) error {
+	var err error
+	err = retry(func() error {
+		_, err = gmailService.Users.Settings.SendAs.Patch(userEmail, sendAsEmail, &gmail.SendAs{IsDefault: true}).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error making send-as address %s the default: %s", sendAsEmail, err)
+	}
+	return nil
+}
+
+func resourceUserGmailSendAsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userEmail := strings.ToLower(d.Get("user_email").(string))
+	sendAsEmail := strings.ToLower(d.Get("send_as_email").(string))
+	gmailService, err := config.gmailService(userEmail)
+	if err != nil {
+		return err
+	}
+
+	var sendAs *gmail.SendAs
+	err = retry(func() error {
+		sendAs, err = gmailService.Users.Settings.SendAs.Get(userEmail, sendAsEmail).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Send-as address %q", sendAsEmail))
+	}
+
+	d.Set("user_email", userEmail)
+	d.Set("send_as_email", strings.ToLower(sendAs.SendAsEmail))
+	d.Set("display_name", sendAs.DisplayName)
+	d.Set("reply_to", sendAs.ReplyToAddress)
+	d.Set("signature", sendAs.Signature)
+	d.Set("is_default", sendAs.IsDefault)
+	d.Set("treat_as_alias", sendAs.TreatAsAlias)
+	d.Set("is_primary", sendAs.IsPrimary)
+	d.Set("verification_status", sendAs.VerificationStatus)
+
+	return nil
+}
+
+func resourceUserGmailSendAsUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userEmail := strings.ToLower(d.Get("user_email").(string))
+	sendAsEmail := strings.ToLower(d.Get("send_as_email").(string))
+	gmailService, err := config.gmailService(userEmail)
+	if err != nil {
+		return err
+	}
+
+	sendAs := &gmail.SendAs{}
+	forceSendFields := []string{}
+
+	if d.HasChange("display_name") {
+		sendAs.DisplayName = d.Get("display_name").(string)
+		forceSendFields = append(forceSendFields, "DisplayName")
+	}
+
+	if d.HasChange("reply_to") {
+		sendAs.ReplyToAddress = d.Get("reply_to").(string)
+		forceSendFields = append(forceSendFields, "ReplyToAddress")
+	}
+
+	if d.HasChange("signature") {
+		sendAs.Signature = d.Get("signature").(string)
+		forceSendFields = append(forceSendFields, "Signature")
+	}
+
+	if d.HasChange("treat_as_alias") {
+		sendAs.TreatAsAlias = d.Get("treat_as_alias").(bool)
+		forceSendFields = append(forceSendFields, "TreatAsAlias")
+	}
+
+	// Another address becomes the default by making it the default, so only
+	// true is sent
+	if d.HasChange("is_default") && d.Get("is_default").(bool) {
+		sendAs.IsDefault = true
+		forceSendFields = append(forceSendFields, "IsDefault")
+	}
+
+	if len(forceSendFields) > 0 {
+		sendAs.ForceSendFields = forceSendFields
+
+		err = retry(func() error {
+			_, err = gmailService.Users.Settings.SendAs.Patch(userEmail, sendAsEmail, sendAs).Do()
+			return err
+		}, config.TimeoutMinutes)
+
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error updating send-as address: %s", err)
+		}
+		log.Printf("[INFO] Updated send-as address %s for %s", sendAsEmail, userEmail)
+	}
+
+	return resourceUserGmailSendAsRead(d, meta)
+}
+
+func resourceUserGmailSendAsDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userEmail := strings.ToLower(d.Get("user_email").(string))
+	sendAsEmail := strings.ToLower(d.Get("send_as_email").(string))
+	gmailService, err := config.gmailService(userEmail)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return gmailService.Users.Settings.SendAs.Delete(userEmail, sendAsEmail).Do()
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Send-as address %q is already gone", sendAsEmail)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error deleting send-as address: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using [user email]{:,/}[send-as email]
+func resourceUserGmailSendAsImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), ":")
+	if len(s) < 2 {
+		s = strings.Split(d.Id(), "/")
+	}
+
+	if len(s) < 2 {
+		return nil, fmt.Errorf("[WARN] Import via [user email]:[send-as email] or [user email]/[send-as email]")
+	}
+	userEmail, sendAsEmail := strings.ToLower(s[0]), strings.ToLower(s[1])
+
+	d.SetId(fmt.Sprintf("%s/%s", userEmail, sendAsEmail))
+	d.Set("user_email", userEmail)
+	d.Set("send_as_email", sendAsEmail)
+
+	return []*schema.ResourceData{d}, nil
+}
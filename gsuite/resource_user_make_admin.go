@@ -0,0 +1,143 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// Super admin status is granted through its own endpoint rather than the user
+// update, so it is managed as its own resource. Destroying the resource
+// revokes the status again.
+func resourceUserMakeAdmin() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserMakeAdminCreate,
+		Read:   resourceUserMakeAdminRead,
+		Update: resourceUserMakeAdminUpdate,
+		Delete: resourceUserMakeAdminDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceUserMakeAdminImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
+			},
+
+			"is_admin": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+// userMakeAdmin grants or revokes the super admin status of a user. Demoting
+// the impersonated user would take away the access of the provider itself, so
+// that is refused.
+func userMakeAdmin(config *Config, userKey string, isAdmin bool) error {
+	if !isAdmin && strings.EqualFold(userKey, config.ImpersonatedUserEmail) {
+		return fmt.Errorf("[ERROR] Refusing to revoke the super admin status of %s, it is the impersonated_user_email of the provider and would lose access to the Admin SDK", userKey)
+	}
+
+	makeAdmin := &directory.UserMakeAdmin{
+		Status:          isAdmin,
+		ForceSendFields: []string{"Status"},
+	}
+
+	var err error
+	err = retry(func() error {
+		err = config.directory.Users.MakeAdmin(userKey, makeAdmin).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating super admin status of user %s: %s", userKey, err)
+	}
+
+	log.Printf("[INFO] Updated super admin status of user %s: %t", userKey, isAdmin)
+	return nil
+}
+
+func resourceUserMakeAdminCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	primaryEmail := strings.ToLower(d.Get("primary_email").(string))
+	if err := userMakeAdmin(config, primaryEmail, d.Get("is_admin").(bool)); err != nil {
+		return err
+	}
+
+	d.SetId(primaryEmail)
+	return resourceUserMakeAdminRead(d, meta)
+}
+
+func resourceUserMakeAdminUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if d.HasChange("is_admin") {
+		if err := userMakeAdmin(config, d.Id(), d.Get("is_admin").(bool)); err != nil {
+			return err
+		}
+	}
+
+	return resourceUserMakeAdminRead(d, meta)
+}
+
+func resourceUserMakeAdminRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var user *directory.User
+	var err error
+	err = retry(func() error {
+		user, err = config.directory.Users.Get(d.Id()).Fields("primaryEmail", "isAdmin").Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("User %q", d.Id()))
+	}
+
+	d.Set("primary_email", strings.ToLower(user.PrimaryEmail))
+	d.Set("is_admin", user.IsAdmin)
+
+	return nil
+}
+
+func resourceUserMakeAdminDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if !d.Get("is_admin").(bool) {
+		d.SetId("")
+		return nil
+	}
+
+	if strings.EqualFold(d.Id(), config.ImpersonatedUserEmail) {
+		log.Printf("[WARN] Not revoking the super admin status of %s, the provider impersonates this user. Removing it from state only", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := userMakeAdmin(config, d.Id(), false); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using the primary email of the user
+func resourceUserMakeAdminImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(strings.ToLower(d.Id()))
+	d.Set("primary_email", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,84 @@
+package gsuite
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// testMakeAdminServer keeps the super admin status of the users, as set
+// through the makeAdmin endpoint.
+func testMakeAdminServer(t *testing.T) (*Config, map[string]bool) {
+	admins := map[string]bool{}
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		parts := strings.Split(r.URL.Path, "/")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/makeAdmin"):
+			var makeAdmin directory.UserMakeAdmin
+			if err := json.NewDecoder(r.Body).Decode(&makeAdmin); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			admins[parts[len(parts)-2]] = makeAdmin.Status
+		case r.Method == http.MethodGet:
+			userKey := parts[len(parts)-1]
+			fmt.Fprintf(w, `{"primaryEmail":%q,"isAdmin":%t}`, userKey, admins[userKey])
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	config.ImpersonatedUserEmail = "admin@domain.ext"
+
+	return config, admins
+}
+
+func TestResourceUserMakeAdmin(t *testing.T) {
+	config, admins := testMakeAdminServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceUserMakeAdmin().Schema, map[string]interface{}{
+		"primary_email": "Break-Glass@Domain.ext",
+	})
+	if err := resourceUserMakeAdminCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d.Id() != "break-glass@domain.ext" || !admins["break-glass@domain.ext"] || !d.Get("is_admin").(bool) {
+		t.Fatalf("expected the user to be made super admin, got id %q and admins %v", d.Id(), admins)
+	}
+
+	if err := resourceUserMakeAdminDelete(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if admin, ok := admins["break-glass@domain.ext"]; !ok || admin {
+		t.Fatalf("expected the super admin status to be revoked, got admins %v", admins)
+	}
+}
+
+func TestResourceUserMakeAdmin_impersonatedUser(t *testing.T) {
+	config, admins := testMakeAdminServer(t)
+	admins["admin@domain.ext"] = true
+
+	d := schema.TestResourceDataRaw(t, resourceUserMakeAdmin().Schema, map[string]interface{}{
+		"primary_email": "admin@domain.ext",
+		"is_admin":      false,
+	})
+	err := resourceUserMakeAdminCreate(d, config)
+	if err == nil || !strings.Contains(err.Error(), "impersonated_user_email") {
+		t.Fatalf("expected an error about demoting the impersonated user, got %v", err)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceUserMakeAdmin().Schema, map[string]interface{}{
+		"primary_email": "Admin@Domain.ext",
+	})
+	d.SetId("admin@domain.ext")
+	if err := resourceUserMakeAdminDelete(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !admins["admin@domain.ext"] || d.Id() != "" {
+		t.Fatalf("expected the impersonated user to stay super admin and be removed from state, got admins %v", admins)
+	}
+}
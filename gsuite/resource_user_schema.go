@@ -83,21 +83,22 @@ func resourceUserSchema() *schema.Resource {
 							Default:  true,
 						},
 
-						"range": {
-							Type:     schema.TypeMap,
+						"min_value": {
+							Type:     schema.TypeFloat,
 							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"min_value": {
-										Type:     schema.TypeFloat,
-										Optional: false,
-									},
-
-									"max_value": {
-										Type:     schema.TypeFloat,
-										Optional: false,
-									},
-								},
+						},
+
+						"max_value": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+						},
+
+						"range": {
+							Type:       schema.TypeMap,
+							Optional:   true,
+							Deprecated: "Use min_value and max_value instead",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
 							},
 						},
 					},
@@ -202,7 +203,9 @@ func resourceUserSchemaRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("schema_id", read.SchemaId)
 	d.Set("schema_name", read.SchemaName)
 	d.Set("display_name", read.DisplayName)
-	d.Set("field", read.Fields)
+	if err := d.Set("field", flattenUserSchemaFieldSpecs(read.Fields, d.Get("field").([]interface{}))); err != nil {
+		return fmt.Errorf("Error setting field in state: %s", err.Error())
+	}
 
 	return nil
 }
@@ -260,19 +263,17 @@ func resourceUserSchemaDelete(d *schema.ResourceData, meta interface{}) error {
 	}, config.TimeoutMinutes)
 }
 
+// Allow importing using the schema name or id, the fields are read like on
+// every refresh so that all their specs are restored
 func resourceUserSchemaImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
+	schemaKey := d.Id()
 
-	imported, err := config.directory.Schemas.Get(config.CustomerId, d.Id()).Do()
-	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error fetching schema. Make sure the schema exists: %s ", err)
+	if err := resourceUserSchemaRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("[ERROR] Error fetching schema. Make sure the schema %s exists", schemaKey)
 	}
-
-	d.SetId(imported.SchemaId)
-	d.Set("schema_id", imported.SchemaId)
-	d.Set("schema_name", imported.SchemaName)
-	d.Set("display_name", imported.DisplayName)
-	d.Set("field", imported.Fields)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -299,49 +300,24 @@ func getUserSchemaFieldSpecs(d *schema.ResourceData) ([]*directory.SchemaFieldSp
 			spec.DisplayName = spec.FieldName
 		}
 
-		if values, ok := fields["range"].(map[string]interface{}); ok {
-			var (
-				minValue float64
-				maxValue float64
-				err      error
-			)
-			switch spec.FieldType {
-			case "DOUBLE":
-				if v, ok := values["min_value"]; ok {
-					minValue, err = strconv.ParseFloat(v.(string), 64)
-					if err != nil {
-						return nil, err
-					}
-				}
-
-				if v, ok := values["max_value"]; ok {
-					maxValue, err = strconv.ParseFloat(v.(string), 64)
-					if err != nil {
-						return nil, err
-					}
-				}
-
-			case "INT64":
-				if v, ok := values["min_value"]; ok {
-					integer, err := strconv.Atoi(v.(string))
-					if err != nil {
-						return nil, err
-					}
-					minValue = float64(integer)
-				}
-				if v, ok := values["max_value"]; ok {
-					integer, err := strconv.Atoi(v.(string))
-					if err != nil {
-						return nil, err
-					}
-					maxValue = float64(integer)
-				}
+		minValue := fields["min_value"].(float64)
+		maxValue := fields["max_value"].(float64)
+		if values, ok := fields["range"].(map[string]interface{}); ok && minValue == 0 && maxValue == 0 {
+			var err error
+			minValue, maxValue, err = parseUserSchemaRange(spec.FieldType, values)
+			if err != nil {
+				return nil, err
 			}
-			if minValue > 0 || maxValue > 0 {
-				spec.NumericIndexingSpec = &directory.SchemaFieldSpecNumericIndexingSpec{
-					MinValue: minValue,
-					MaxValue: maxValue,
-				}
+		}
+
+		if minValue != 0 || maxValue != 0 {
+			if spec.FieldType != "DOUBLE" && spec.FieldType != "INT64" {
+				return nil, fmt.Errorf("[ERROR] min_value and max_value are only supported on DOUBLE and INT64 fields, not on %s field %q", spec.FieldType, spec.FieldName)
+			}
+			spec.NumericIndexingSpec = &directory.SchemaFieldSpecNumericIndexingSpec{
+				MinValue:        minValue,
+				MaxValue:        maxValue,
+				ForceSendFields: []string{"MinValue", "MaxValue"},
 			}
 		}
 
@@ -350,3 +326,77 @@ func getUserSchemaFieldSpecs(d *schema.ResourceData) ([]*directory.SchemaFieldSp
 
 	return specs, nil
 }
+
+// parseUserSchemaRange reads the deprecated range map, which holds its values
+// as strings.
+func parseUserSchemaRange(fieldType string, values map[string]interface{}) (float64, float64, error) {
+	var minValue, maxValue float64
+	for key, target := range map[string]*float64{"min_value": &minValue, "max_value": &maxValue} {
+		v, ok := values[key]
+		if !ok {
+			continue
+		}
+		switch fieldType {
+		case "DOUBLE":
+			value, err := strconv.ParseFloat(v.(string), 64)
+			if err != nil {
+				return 0, 0, err
+			}
+			*target = value
+		case "INT64":
+			value, err := strconv.Atoi(v.(string))
+			if err != nil {
+				return 0, 0, err
+			}
+			*target = float64(value)
+		}
+	}
+	return minValue, maxValue, nil
+}
+
+// flattenUserSchemaFieldSpecs converts the fields returned by the API to
+// state. The deprecated range map is not returned by the API, so it's kept as
+// configured in the current state.
+func flattenUserSchemaFieldSpecs(specs []*directory.SchemaFieldSpec, stateFields []interface{}) []map[string]interface{} {
+	stateRanges := make(map[string]interface{})
+	for _, raw := range stateFields {
+		if field, ok := raw.(map[string]interface{}); ok {
+			stateRanges[field["field_name"].(string)] = field["range"]
+		}
+	}
+
+	fields := make([]map[string]interface{}, 0, len(specs))
+	for _, spec := range specs {
+		field := map[string]interface{}{
+			"field_name":       spec.FieldName,
+			"display_name":     spec.DisplayName,
+			"field_type":       spec.FieldType,
+			"multi_valued":     spec.MultiValued,
+			"read_access_type": spec.ReadAccessType,
+			"indexed":          spec.Indexed == nil || *spec.Indexed,
+		}
+
+		// The API leaves out the default access type
+		if spec.ReadAccessType == "" {
+			field["read_access_type"] = "ADMINS_AND_SELF"
+		}
+
+		if spec.NumericIndexingSpec != nil {
+			field["min_value"] = spec.NumericIndexingSpec.MinValue
+			field["max_value"] = spec.NumericIndexingSpec.MaxValue
+		}
+
+		if r, ok := stateRanges[spec.FieldName]; ok && r != nil {
+			field["range"] = r
+			// Don't report the values of the deprecated range map as a diff
+			// on min_value and max_value
+			if len(r.(map[string]interface{})) > 0 {
+				delete(field, "min_value")
+				delete(field, "max_value")
+			}
+		}
+
+		fields = append(fields, field)
+	}
+	return fields
+}
@@ -0,0 +1,170 @@
+package gsuite
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestUserSchemaFieldSpecs_numericRoundTrip(t *testing.T) {
+	raw := map[string]interface{}{
+		"schema_name": "employee",
+		"field": []interface{}{
+			map[string]interface{}{
+				"field_name": "level",
+				"field_type": "INT64",
+				"min_value":  1.0,
+				"max_value":  10.0,
+			},
+			map[string]interface{}{
+				"field_name": "nickname",
+				"field_type": "STRING",
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceUserSchema().Schema, raw)
+
+	specs, err := getUserSchemaFieldSpecs(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(specs) != 2 {
+		t.Fatalf("expected 2 field specs, got %d", len(specs))
+	}
+	if spec := specs[0].NumericIndexingSpec; spec == nil || spec.MinValue != 1 || spec.MaxValue != 10 {
+		t.Fatalf("expected numeric indexing spec 1-10 on %s, got %+v", specs[0].FieldName, spec)
+	}
+	if specs[1].NumericIndexingSpec != nil {
+		t.Fatalf("expected no numeric indexing spec on %s", specs[1].FieldName)
+	}
+
+	// Read the specs back the same way the API returns them
+	if err := d.Set("field", flattenUserSchemaFieldSpecs(specs, d.Get("field").([]interface{}))); err != nil {
+		t.Fatalf("unexpected error setting field: %s", err)
+	}
+	if v := d.Get("field.0.min_value").(float64); v != 1 {
+		t.Errorf("expected min_value 1, got %v", v)
+	}
+	if v := d.Get("field.0.max_value").(float64); v != 10 {
+		t.Errorf("expected max_value 10, got %v", v)
+	}
+	if v := d.Get("field.1.max_value").(float64); v != 0 {
+		t.Errorf("expected no max_value on %s, got %v", specs[1].FieldName, v)
+	}
+}
+
+func TestUserSchemaFieldSpecs_deprecatedRange(t *testing.T) {
+	raw := map[string]interface{}{
+		"schema_name": "employee",
+		"field": []interface{}{
+			map[string]interface{}{
+				"field_name": "salary",
+				"field_type": "DOUBLE",
+				"range": map[string]interface{}{
+					"min_value": "0.5",
+					"max_value": "100.5",
+				},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceUserSchema().Schema, raw)
+
+	specs, err := getUserSchemaFieldSpecs(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if spec := specs[0].NumericIndexingSpec; spec == nil || spec.MinValue != 0.5 || spec.MaxValue != 100.5 {
+		t.Fatalf("expected numeric indexing spec 0.5-100.5, got %+v", spec)
+	}
+
+	if err := d.Set("field", flattenUserSchemaFieldSpecs(specs, d.Get("field").([]interface{}))); err != nil {
+		t.Fatalf("unexpected error setting field: %s", err)
+	}
+	if v := d.Get("field.0.range.max_value").(string); v != "100.5" {
+		t.Errorf("expected range to be kept, got %q", v)
+	}
+}
+
+func TestUserSchemaFieldSpecs_numericOnNonNumericField(t *testing.T) {
+	raw := map[string]interface{}{
+		"schema_name": "employee",
+		"field": []interface{}{
+			map[string]interface{}{
+				"field_name": "nickname",
+				"field_type": "STRING",
+				"max_value":  10.0,
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceUserSchema().Schema, raw)
+
+	if _, err := getUserSchemaFieldSpecs(d); err == nil {
+		t.Fatal("expected an error for min_value/max_value on a STRING field")
+	}
+}
+
+func TestResourceUserSchemaImporter(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/customer/my_customer/schemas/employee") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"schemaId":"schema-id","schemaName":"employee","displayName":"Employee","fields":[
+			{"fieldName":"level","displayName":"Level","fieldType":"INT64","indexed":true,"readAccessType":"ALL_DOMAIN_USERS","numericIndexingSpec":{"minValue":1,"maxValue":10}},
+			{"fieldName":"projects","displayName":"Projects","fieldType":"STRING","multiValued":true,"indexed":false},
+			{"fieldName":"nickname","displayName":"nickname","fieldType":"STRING"}
+		]}`)
+	})
+	config.CustomerId = "my_customer"
+
+	r := resourceUserSchema()
+	d := r.Data(nil)
+	d.SetId("employee")
+
+	imported, err := resourceUserSchemaImporter(d, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if imported[0].Id() != "schema-id" {
+		t.Fatalf("expected the schema id as ID, got %s", imported[0].Id())
+	}
+
+	state := imported[0].State()
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"schema_name":  "employee",
+		"display_name": "Employee",
+		"field": []interface{}{
+			map[string]interface{}{
+				"field_name":       "level",
+				"display_name":     "Level",
+				"field_type":       "INT64",
+				"read_access_type": "ALL_DOMAIN_USERS",
+				"min_value":        1,
+				"max_value":        10,
+			},
+			map[string]interface{}{
+				"field_name":   "projects",
+				"display_name": "Projects",
+				"field_type":   "STRING",
+				"multi_valued": true,
+				"indexed":      false,
+			},
+			map[string]interface{}{
+				"field_name": "nickname",
+				"field_type": "STRING",
+			},
+		},
+	})
+
+	diff, err := r.Diff(state, cfg, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff after the import, got %#v", diff.Attributes)
+	}
+}
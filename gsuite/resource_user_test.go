@@ -0,0 +1,1222 @@
+package gsuite
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/sethvargo/go-password/password"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestAccResourceUser_suspension(t *testing.T) {
+	domainName := os.Getenv(testAccDomainEnvVar)
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if domainName == "" {
+				t.Skipf("%s must be set for user acceptance tests", testAccDomainEnvVar)
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserSuspensionConfig(name, domainName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserSuspended("gsuite_user.test", true),
+					resource.TestCheckResourceAttr("gsuite_user.test", "is_suspended", "true"),
+					resource.TestCheckResourceAttrSet("gsuite_user.test", "suspension_reason"),
+				),
+			},
+			{
+				Config: testAccResourceUserSuspensionConfig(name, domainName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserSuspended("gsuite_user.test", false),
+					resource.TestCheckResourceAttr("gsuite_user.test", "is_suspended", "false"),
+					resource.TestCheckResourceAttr("gsuite_user.test", "name.given_name", "Test"),
+				),
+			},
+			{
+				Config: testAccResourceUserSuspensionConfig(name, domainName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserSuspended("gsuite_user.test", true),
+					resource.TestCheckResourceAttr("gsuite_user.test", "is_suspended", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckUserSuspended(n string, suspended bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		user, err := config.directory.Users.Get(rs.Primary.ID).Do()
+		if err != nil {
+			return err
+		}
+		if user.Suspended != suspended {
+			return fmt.Errorf("User %s: expected suspended to be %t, got %t", rs.Primary.ID, suspended, user.Suspended)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckUserDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gsuite_user" {
+			continue
+		}
+
+		_, err := config.directory.Users.Get(rs.Primary.ID).Do()
+		if err == nil {
+			return fmt.Errorf("User %s still exists", rs.Primary.ID)
+		}
+		if !strings.Contains(err.Error(), "404") {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccResourceUserSuspensionConfig(name, domainName string, suspended bool) string {
+	return fmt.Sprintf(`
+resource "gsuite_user" "test" {
+  primary_email = "%[1]s@%[2]s"
+  is_suspended  = %[3]t
+
+  name = {
+    family_name = "User"
+    given_name  = "Test"
+  }
+}
+`, name, domainName, suspended)
+}
+
+func TestAccResourceUser_archived(t *testing.T) {
+	domainName := os.Getenv(testAccDomainEnvVar)
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if domainName == "" {
+				t.Skipf("%s must be set for user acceptance tests", testAccDomainEnvVar)
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserArchivedConfig(name, domainName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserArchived("gsuite_user.test", true),
+					resource.TestCheckResourceAttr("gsuite_user.test", "archived", "true"),
+				),
+			},
+			{
+				Config: testAccResourceUserArchivedConfig(name, domainName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserArchived("gsuite_user.test", false),
+					resource.TestCheckResourceAttr("gsuite_user.test", "archived", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckUserArchived(n string, archived bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		user, err := config.directory.Users.Get(rs.Primary.ID).Do()
+		if err != nil {
+			return err
+		}
+		if user.Archived != archived {
+			return fmt.Errorf("User %s: expected archived to be %t, got %t", rs.Primary.ID, archived, user.Archived)
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceUserArchivedConfig(name, domainName string, archived bool) string {
+	return fmt.Sprintf(`
+resource "gsuite_user" "test" {
+  primary_email = "%[1]s@%[2]s"
+  archived      = %[3]t
+
+  name = {
+    family_name = "User"
+    given_name  = "Test"
+  }
+}
+`, name, domainName, archived)
+}
+
+func TestCustomSchemaValueDiffSuppress(t *testing.T) {
+	testCases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{`{"level":"3"}`, `{ "level": "3" }`, true},
+		{`{"level":"3"}`, `{"level":"4"}`, false},
+		{
+			`{"phones":[{"value":"555-0001"},{"value":"555-0002"}]}`,
+			`{"phones":[{"value":"555-0002"},{"value":"555-0001"}]}`,
+			true,
+		},
+		{
+			`{"integers":[{"value":1002},{"value":1001}]}`,
+			`{"integers":[{"value":1001},{"value":1002}]}`,
+			true,
+		},
+		{
+			`{"phones":[{"value":"555-0001"}]}`,
+			`{"phones":[{"value":"555-0001"},{"value":"555-0002"}]}`,
+			false,
+		},
+		{`{"level":"3"}`, `not json`, false},
+	}
+
+	for _, testCase := range testCases {
+		if suppress := customSchemaValueDiffSuppress("custom_schema.0.value", testCase.old, testCase.new, nil); suppress != testCase.suppress {
+			t.Errorf("expected suppress %t for %s -> %s, got %t", testCase.suppress, testCase.old, testCase.new, suppress)
+		}
+	}
+}
+
+// apiUserField encodes v the way the API client decodes the untyped JSON
+// arrays of a user.
+func apiUserField(t *testing.T, v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return decoded
+}
+
+// sortedJSON returns the JSON encoding of every element, so that the blocks
+// stored as sets can be compared regardless of their order.
+func sortedJSON(t *testing.T, v interface{}) []string {
+	var elements []interface{}
+	if err := decodeUserField(v, &elements); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	encoded := []string{}
+	for _, e := range elements {
+		b, _ := json.Marshal(e)
+		encoded = append(encoded, string(b))
+	}
+	sort.Strings(encoded)
+	return encoded
+}
+
+func TestUserBlocks_roundTrip(t *testing.T) {
+	posixAccounts := []*directory.UserPosixAccount{
+		{Username: "jdoe", Uid: 1001, Gid: 1001, HomeDirectory: "/home/jdoe", Shell: "/bin/bash", SystemId: "web", Primary: true},
+		{Username: "jdoe", Uid: 0, Gid: 100, HomeDirectory: "/root", Shell: "/bin/sh", Gecos: "John Doe", SystemId: "db"},
+	}
+	sshPublicKeys := []*directory.UserSshPublicKey{
+		{Key: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAII7BI26F9cmPGga9n8boQgtvcnpCfMEEF31/wtEY38K5 jdoe@example.com", ExpirationTimeUsec: 1893456000000000},
+		{Key: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAII7BI26F9cmPGga9n8boQgtvcnpCfMEEF31/wtEY38K5 backup"},
+	}
+	externalIDs := []*directory.UserExternalId{
+		{Value: "12345", Type: "organization"},
+		{Value: "jdoe", Type: "custom", CustomType: "hr_system"},
+	}
+	relations := []*directory.UserRelation{
+		{Value: "manager@domain.ext", Type: "manager"},
+		{Value: "buddy@domain.ext", Type: "custom", CustomType: "onboarding_buddy"},
+	}
+	languages := []*userLanguage{
+		{LanguageCode: "en-GB", Preference: "preferred"},
+		{LanguageCode: "nl"},
+		{CustomLanguage: "Frisian", Preference: "not_preferred"},
+	}
+	keywords := []*directory.UserKeyword{
+		{Value: "Engineer", Type: "occupation"},
+		{Value: "platform", Type: "custom", CustomType: "team"},
+	}
+	websites := []*directory.UserWebsite{
+		{Value: "https://jdoe.example.com", Type: "home_page", Primary: true},
+		{Value: "https://wiki.domain.ext/jdoe", Type: "custom", CustomType: "wiki"},
+	}
+	ims := []*directory.UserIm{
+		{Im: "jdoe@domain.ext", Protocol: "jabber", Type: "work", Primary: true},
+		{Im: "@jdoe:matrix.org", Protocol: "custom_protocol", CustomProtocol: "matrix", Type: "custom", CustomType: "chat"},
+	}
+	gender := &directory.UserGender{Type: "other", CustomGender: "non-binary", AddressMeAs: "they/them"}
+	organizations := []*directory.UserOrganization{
+		{Name: "Example", Department: "Engineering", Title: "Engineer", FullTimeEquivalent: 100000, Primary: true, Type: "work"},
+		{Name: "University", Type: "school"},
+	}
+	phones := []*directory.UserPhone{
+		{Value: "+31201234567", Type: "work", Primary: true},
+		{Value: "+31612345678", Type: "mobile"},
+		{Value: "+31101234567", Type: "custom", CustomType: "desk"},
+	}
+	addresses := []*directory.UserAddress{
+		{StreetAddress: "Kalvermarkt 1", Locality: "Amsterdam", PostalCode: "1012 AB", Country: "Netherlands", CountryCode: "NL", SourceIsStructured: true, Primary: true, Type: "work"},
+		{Formatted: "Somewhere 2, 1234 CD Utrecht", Type: "home"},
+	}
+	emails := []*directory.UserEmail{
+		{Address: "user@domain.ext", Primary: true},
+		{Address: "alias@domain.ext"},
+		{Address: "user@private.ext", Type: "home"},
+		{Address: "user@other.ext", Type: "custom", CustomType: "backup"},
+	}
+
+	user := &directory.User{
+		PrimaryEmail:  "user@domain.ext",
+		Aliases:       []string{"alias@domain.ext"},
+		PosixAccounts: apiUserField(t, posixAccounts),
+		SshPublicKeys: apiUserField(t, sshPublicKeys),
+		ExternalIds:   apiUserField(t, externalIDs),
+		Relations:     apiUserField(t, relations),
+		Languages:     apiUserField(t, languages),
+		Keywords:      apiUserField(t, keywords),
+		Websites:      apiUserField(t, websites),
+		Ims:           apiUserField(t, ims),
+		Gender:        apiUserField(t, gender),
+		Organizations: apiUserField(t, organizations),
+		Phones:        apiUserField(t, phones),
+		Addresses:     apiUserField(t, addresses),
+		Emails:        apiUserField(t, emails),
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{})
+	if err := flattenUserBlocks(d, user); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	t.Run("posix_accounts", func(t *testing.T) {
+		got := expandUserPosixAccounts(d.Get("posix_accounts").([]interface{}))
+		if !reflect.DeepEqual(got, posixAccounts) {
+			t.Fatalf("expected posix_accounts %s, got %s", apiUserField(t, posixAccounts), apiUserField(t, got))
+		}
+	})
+
+	t.Run("ssh_public_keys", func(t *testing.T) {
+		got := expandUserSSHPublicKeys(d.Get("ssh_public_keys").([]interface{}))
+		if !reflect.DeepEqual(got, sshPublicKeys) {
+			t.Fatalf("expected ssh_public_keys %s, got %s", apiUserField(t, sshPublicKeys), apiUserField(t, got))
+		}
+	})
+
+	t.Run("external_ids", func(t *testing.T) {
+		got := expandUserExternalIds(d.Get("external_ids").([]interface{}))
+		if !reflect.DeepEqual(got, externalIDs) {
+			t.Fatalf("expected external_ids %s, got %s", apiUserField(t, externalIDs), apiUserField(t, got))
+		}
+	})
+
+	t.Run("relations", func(t *testing.T) {
+		got := expandUserRelations(d.Get("relations").([]interface{}))
+		if !reflect.DeepEqual(got, relations) {
+			t.Fatalf("expected relations %s, got %s", apiUserField(t, relations), apiUserField(t, got))
+		}
+	})
+
+	t.Run("languages", func(t *testing.T) {
+		got := expandUserLanguages(d.Get("languages").([]interface{}))
+		if !reflect.DeepEqual(got, languages) {
+			t.Fatalf("expected languages %s, got %s", apiUserField(t, languages), apiUserField(t, got))
+		}
+	})
+
+	t.Run("keywords", func(t *testing.T) {
+		got := expandUserKeywords(d.Get("keywords").([]interface{}))
+		if !reflect.DeepEqual(got, keywords) {
+			t.Fatalf("expected keywords %s, got %s", apiUserField(t, keywords), apiUserField(t, got))
+		}
+	})
+
+	t.Run("websites", func(t *testing.T) {
+		got := expandUserWebsites(d.Get("websites").([]interface{}))
+		if !reflect.DeepEqual(got, websites) {
+			t.Fatalf("expected websites %s, got %s", apiUserField(t, websites), apiUserField(t, got))
+		}
+	})
+
+	t.Run("ims", func(t *testing.T) {
+		got := expandUserIms(d.Get("ims").([]interface{}))
+		if !reflect.DeepEqual(got, ims) {
+			t.Fatalf("expected ims %s, got %s", apiUserField(t, ims), apiUserField(t, got))
+		}
+	})
+
+	t.Run("gender", func(t *testing.T) {
+		got := expandUserGender(d.Get("gender").([]interface{}))
+		if !reflect.DeepEqual(got, gender) {
+			t.Fatalf("expected gender %s, got %s", apiUserField(t, gender), apiUserField(t, got))
+		}
+	})
+
+	t.Run("organizations", func(t *testing.T) {
+		// The order of the organizations is kept
+		got := expandUserOrganizations(d.Get("organizations").([]interface{}))
+		if !reflect.DeepEqual(got, organizations) {
+			t.Fatalf("expected organizations %s, got %s", apiUserField(t, organizations), apiUserField(t, got))
+		}
+	})
+
+	t.Run("phones", func(t *testing.T) {
+		got := sortedJSON(t, expandUserPhones(d.Get("phones").(*schema.Set).List()))
+		if want := sortedJSON(t, phones); !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected phones %v, got %v", want, got)
+		}
+	})
+
+	t.Run("addresses", func(t *testing.T) {
+		got := sortedJSON(t, expandUserAddresses(d.Get("addresses").(*schema.Set).List()))
+		if want := sortedJSON(t, addresses); !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected addresses %v, got %v", want, got)
+		}
+	})
+
+	t.Run("emails", func(t *testing.T) {
+		// The primary email and the aliases are left out
+		got := sortedJSON(t, expandUserEmails(d.Get("emails").(*schema.Set).List()))
+		if want := sortedJSON(t, emails[2:]); !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected emails %v, got %v", want, got)
+		}
+	})
+}
+
+// testUserServer fakes a directory API in which the user already exists but is
+// not listed yet, and records the calls it received.
+func testUserServer(t *testing.T) (*Config, *[]string) {
+	var mu sync.Mutex
+	calls := []string{}
+
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+string(body))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/users"):
+			fmt.Fprint(w, `{"users":[]}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/users"):
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"error":{"code":409,"message":"Entity already exists.","errors":[{"reason":"duplicate","message":"Entity already exists."}]}}`)
+		default:
+			fmt.Fprint(w, `{"id":"existing-id","primaryEmail":"existing@domain.ext","name":{"familyName":"Doe","givenName":"John"}}`)
+		}
+	})
+
+	return config, &calls
+}
+
+func TestResourceUserCreate_updateExisting(t *testing.T) {
+	testCases := []struct {
+		updateExisting bool
+		adopted        bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, testCase := range testCases {
+		config, calls := testUserServer(t)
+		config.UpdateExisting = testCase.updateExisting
+
+		d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+			"primary_email": "existing@domain.ext",
+			"name": map[string]interface{}{
+				"family_name": "Doe",
+				"given_name":  "John",
+			},
+		})
+		err := resourceUserCreate(d, config)
+
+		var updates []string
+		for _, call := range *calls {
+			if strings.HasPrefix(call, http.MethodPut+" ") && strings.Contains(call, "/users/existing-id ") {
+				updates = append(updates, call)
+			}
+		}
+
+		if testCase.adopted {
+			if err != nil {
+				t.Fatalf("expected the existing user to be adopted, got %s", err)
+			}
+			if d.Id() != "existing-id" || len(updates) != 1 {
+				t.Fatalf("expected the existing user to be adopted and updated, got id %q and calls %v", d.Id(), *calls)
+			}
+			if strings.Contains(updates[0], `"password"`) {
+				t.Errorf("expected the password of the existing user to be left alone, got %s", updates[0])
+			}
+		} else {
+			if err == nil || !strings.Contains(err.Error(), "Entity already exists.") {
+				t.Fatalf("expected the duplicate error to be returned, got %v", err)
+			}
+			if d.Id() != "" || len(updates) != 0 {
+				t.Errorf("expected the existing user to be left alone, got id %q and calls %v", d.Id(), *calls)
+			}
+		}
+	}
+}
+
+func TestGenerateUserPassword(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		generated, err := generateUserPassword()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if len(generated) < 16 {
+			t.Fatalf("expected at least 16 characters, got %q", generated)
+		}
+		for _, class := range []string{password.LowerLetters, password.UpperLetters, password.Digits, password.Symbols} {
+			if !strings.ContainsAny(generated, class) {
+				t.Fatalf("expected %q to contain one of %q", generated, class)
+			}
+		}
+	}
+}
+
+func TestResourceUserCreate_generatePassword(t *testing.T) {
+	testCases := []struct {
+		generatePassword bool
+		generated        bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, testCase := range testCases {
+		var inserted directory.User
+		config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/users") {
+				json.NewDecoder(r.Body).Decode(&inserted)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id":"new-id","primaryEmail":"new@domain.ext","name":{"familyName":"Doe","givenName":"John"}}`)
+		})
+
+		d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+			"primary_email": "new@domain.ext",
+			"name": map[string]interface{}{
+				"family_name": "Doe",
+				"given_name":  "John",
+			},
+			"generate_password": testCase.generatePassword,
+		})
+		if err := resourceUserCreate(d, config); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if inserted.Password == "" || !inserted.ChangePasswordAtNextLogin {
+			t.Fatalf("expected a password to be generated and changed at next login, got %+v", inserted)
+		}
+		generated := d.Get("generated_password").(string)
+		if testCase.generated && generated != inserted.Password {
+			t.Errorf("expected generated_password to be the password sent, got %q", generated)
+		}
+		if !testCase.generated && generated != "" {
+			t.Errorf("expected generated_password to stay empty, got %q", generated)
+		}
+	}
+}
+
+func TestResourceUserDiff_generatePassword(t *testing.T) {
+	r := resourceUser()
+	_, err := r.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"primary_email": "new@domain.ext",
+		"name": map[string]interface{}{
+			"family_name": "Doe",
+			"given_name":  "John",
+		},
+		"generate_password":             true,
+		"change_password_at_next_login": false,
+	}), &Config{})
+	if err == nil || !strings.Contains(err.Error(), "change_password_at_next_login") {
+		t.Fatalf("expected generate_password to require change_password_at_next_login, got %v", err)
+	}
+
+	// change_password_at_next_login defaults to true for new users
+	_, err = r.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"primary_email": "new@domain.ext",
+		"name": map[string]interface{}{
+			"family_name": "Doe",
+			"given_name":  "John",
+		},
+		"generate_password": true,
+	}), &Config{SkipDomainCheck: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestValidateUserPasswordHash(t *testing.T) {
+	testCases := []struct {
+		hashFunction string
+		password     string
+		valid        bool
+	}{
+		{"", "plain text password", true},
+		{"MD5", "", true},
+		{"MD5", "5f4dcc3b5aa765d61d8327deb882cf99", true},
+		{"MD5", "5f4dcc3b5aa765d61d8327deb882cf9", false},
+		{"MD5", "password", false},
+		{"SHA-1", "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8", true},
+		{"SHA-1", "5f4dcc3b5aa765d61d8327deb882cf99", false},
+		{"crypt", "$6$saltsalt$qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/", true},
+		{"crypt", "$2b$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", true},
+		{"crypt", "abJnggxhB/yWI", true},
+		{"crypt", "password", false},
+	}
+
+	for _, testCase := range testCases {
+		err := validateUserPasswordHash(testCase.hashFunction, testCase.password)
+		if testCase.valid && err != nil {
+			t.Errorf("expected %q hashed with %q to be valid, got %s", testCase.password, testCase.hashFunction, err)
+		}
+		if !testCase.valid && err == nil {
+			t.Errorf("expected %q hashed with %q to be invalid", testCase.password, testCase.hashFunction)
+		}
+	}
+}
+
+func TestValidateUserLanguages(t *testing.T) {
+	valid := []interface{}{
+		map[string]interface{}{"language_code": "en", "custom_language": "", "preference": "preferred"},
+		map[string]interface{}{"language_code": "", "custom_language": "Frisian", "preference": ""},
+	}
+	if err := validateUserLanguages(valid); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	invalid := append(valid, map[string]interface{}{"language_code": "fy", "custom_language": "Frisian", "preference": ""})
+	if err := validateUserLanguages(invalid); err == nil || !strings.Contains(err.Error(), "languages.2") {
+		t.Errorf("expected an error about languages.2, got %v", err)
+	}
+}
+
+func TestValidateUserGender(t *testing.T) {
+	valid := []interface{}{
+		map[string]interface{}{"type": "other", "custom_gender": "non-binary", "address_me_as": "they/them"},
+	}
+	if err := validateUserGender(valid); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	invalid := []interface{}{
+		map[string]interface{}{"type": "female", "custom_gender": "non-binary", "address_me_as": ""},
+	}
+	if err := validateUserGender(invalid); err == nil || !strings.Contains(err.Error(), "custom_gender") {
+		t.Errorf("expected an error about custom_gender, got %v", err)
+	}
+}
+
+func TestResourceUserDiff_includeInGlobalAddressList(t *testing.T) {
+	testCases := map[string]struct {
+		config   map[string]interface{}
+		included string
+		expected string
+	}{
+		"removed":            {map[string]interface{}{}, "false", "true"},
+		"unchanged":          {map[string]interface{}{"include_in_global_address_list": false}, "false", ""},
+		"deprecated":         {map[string]interface{}{"include_in_global_list": false}, "false", ""},
+		"deprecated changed": {map[string]interface{}{"include_in_global_list": false}, "true", "false"},
+	}
+
+	for tn, tc := range testCases {
+		state := &terraform.InstanceState{
+			ID: "existing-id",
+			Attributes: map[string]string{
+				"id":                             "existing-id",
+				"primary_email":                  "existing@domain.ext",
+				"name.#":                         "1",
+				"name.0.family_name":             "Doe",
+				"name.0.given_name":              "John",
+				"include_in_global_address_list": tc.included,
+				"include_in_global_list":         tc.included,
+			},
+		}
+
+		raw := map[string]interface{}{
+			"primary_email": "existing@domain.ext",
+			"name": []interface{}{
+				map[string]interface{}{
+					"family_name": "Doe",
+					"given_name":  "John",
+				},
+			},
+		}
+		for k, v := range tc.config {
+			raw[k] = v
+		}
+
+		r := resourceUser()
+		diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), &Config{})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tn, err)
+		}
+
+		changed := diff != nil && (diff.Attributes["include_in_global_address_list"] != nil || diff.Attributes["include_in_global_list"] != nil)
+		if tc.expected == "" && changed {
+			t.Errorf("%s: expected no diff, got %v", tn, diff.Attributes)
+		}
+		if tc.expected != "" {
+			if !changed {
+				t.Fatalf("%s: expected a diff", tn)
+			}
+			d, err := schema.InternalMap(r.Schema).Data(state, diff)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %s", tn, err)
+			}
+			if included := strconv.FormatBool(userIncludeInGlobalAddressList(d)); included != tc.expected {
+				t.Errorf("%s: expected the user to be updated to %s, got %s", tn, tc.expected, included)
+			}
+		}
+	}
+}
+
+func TestSSHPublicKeyFingerprint(t *testing.T) {
+	testCases := map[string]string{
+		"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINRQ74URVoSWkJITuG9KqLbCxNefETzaYO4rVMC51toC jdoe": "SHA256:WAKvt1U7Dd26mIacJuLzganyWYn9BMNY3maTS8pQjKQ",
+		"ssh-ed25519 not-base64": "invalid key",
+		"":                       "invalid key",
+	}
+	for key, expected := range testCases {
+		if fingerprint := sshPublicKeyFingerprint(key); fingerprint != expected {
+			t.Errorf("%q: expected fingerprint %s, got %s", key, expected, fingerprint)
+		}
+	}
+}
+
+func TestSetUserIncludeInGlobalAddressList(t *testing.T) {
+	for _, include := range []bool{true, false} {
+		user := &directory.User{}
+		setUserIncludeInGlobalAddressList(user, include)
+
+		encoded, err := json.Marshal(user)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		expected := fmt.Sprintf(`"includeInGlobalAddressList":%t`, include)
+		if !strings.Contains(string(encoded), expected) {
+			t.Errorf("expected %s to contain %s", encoded, expected)
+		}
+	}
+}
+
+func TestResourceUser_readOnlyFlags(t *testing.T) {
+	s := resourceUser().Schema
+	for _, k := range []string{"ip_whitelisted", "is_admin", "is_delegated_admin", "is_enforced_in_2sv", "is_enrolled_in_2sv"} {
+		if !s[k].Computed || s[k].Optional || s[k].Required {
+			t.Errorf("expected %s to be read-only", k)
+		}
+	}
+}
+
+func TestResourceUserImporter_customSchema(t *testing.T) {
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("projection") != "full" || q.Get("viewType") != "admin_view" {
+			t.Errorf("expected the full admin view, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"id": "123",
+			"primaryEmail": "jdoe@domain.ext",
+			"name": {"familyName": "Doe", "givenName": "John"},
+			"customSchemas": {
+				"Employment": {"costCenter": "42", "badges": [{"value": "b"}, {"value": "a"}]}
+			}
+		}`)
+	})
+
+	d := resourceUser().TestResourceData()
+	d.SetId("jdoe@domain.ext")
+	imported, err := resourceUserImporter(d, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	d = imported[0]
+	if d.Id() != "123" {
+		t.Errorf("expected the ID of the user, got %q", d.Id())
+	}
+	if n := d.Get("custom_schema.#").(int); n != 1 {
+		t.Fatalf("expected the custom schema to be imported, got %d custom schemas", n)
+	}
+	if v := d.Get("custom_schema.0.name").(string); v != "Employment" {
+		t.Errorf("expected the Employment custom schema, got %q", v)
+	}
+	value := d.Get("custom_schema.0.value").(string)
+	for _, expected := range []string{`"costCenter":"42"`, `"badges"`} {
+		if !strings.Contains(value, expected) {
+			t.Errorf("expected the custom schema value %s to contain %s", value, expected)
+		}
+	}
+}
+
+func TestResourceUserDiff_emailCase(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "123",
+		Attributes: map[string]string{
+			"id":                 "123",
+			"primary_email":      "jdoe@domain.ext",
+			"name.#":             "1",
+			"name.0.family_name": "Doe",
+			"name.0.given_name":  "John",
+			"aliases.#":          "1",
+			fmt.Sprintf("aliases.%d", hashLowercaseEmail("john@domain.ext")): "john@domain.ext",
+		},
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"primary_email": "JDoe@Domain.ext",
+		"name": []interface{}{
+			map[string]interface{}{
+				"family_name": "Doe",
+				"given_name":  "John",
+			},
+		},
+		"aliases": []interface{}{"John@Domain.ext"},
+	})
+
+	diff, err := resourceUser().Diff(state, config, &Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for k, attr := range diff.Attributes {
+		if strings.HasPrefix(k, "primary_email") || strings.HasPrefix(k, "aliases") {
+			t.Errorf("expected no diff for differently cased email addresses, got %s: %#v", k, attr)
+		}
+	}
+}
+
+func TestResourceUserUpdate_passwordPatch(t *testing.T) {
+	testCases := map[string]struct {
+		password string
+		config   map[string]interface{}
+		contains []string
+		excludes []string
+	}{
+		"changePasswordAtNextLogin": {
+			config: map[string]interface{}{
+				"password":                      "secret",
+				"change_password_at_next_login": false,
+			},
+			contains: []string{`"changePasswordAtNextLogin":false`},
+			excludes: []string{`"password"`, `"hashFunction"`},
+		},
+		"hashFunction": {
+			password: "5ebe2294ecd0e0f08eab7690d2a6ee69",
+			config: map[string]interface{}{
+				"password":      "5ebe2294ecd0e0f08eab7690d2a6ee69",
+				"hash_function": "MD5",
+			},
+			excludes: []string{`"password"`, `"hashFunction"`, `"changePasswordAtNextLogin"`},
+		},
+		"password": {
+			config: map[string]interface{}{
+				"password": "new-secret",
+			},
+			contains: []string{`"password":"new-secret"`},
+			excludes: []string{`"changePasswordAtNextLogin"`},
+		},
+	}
+
+	for tn, tc := range testCases {
+		meta, calls := testUserServer(t)
+
+		password := tc.password
+		if password == "" {
+			password = "secret"
+		}
+
+		state := &terraform.InstanceState{
+			ID: "existing-id",
+			Attributes: map[string]string{
+				"id":                            "existing-id",
+				"primary_email":                 "existing@domain.ext",
+				"name.#":                        "1",
+				"name.0.family_name":            "Doe",
+				"name.0.given_name":             "John",
+				"password":                      password,
+				"change_password_at_next_login": "true",
+			},
+		}
+
+		raw := map[string]interface{}{
+			"primary_email": "existing@domain.ext",
+			"name": []interface{}{
+				map[string]interface{}{
+					"family_name": "Doe",
+					"given_name":  "John",
+				},
+			},
+		}
+		for k, v := range tc.config {
+			raw[k] = v
+		}
+		config := terraform.NewResourceConfigRaw(raw)
+
+		r := resourceUser()
+		diff, err := r.Diff(state, config, meta)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tn, err)
+		}
+		if _, err := r.Apply(state, diff, meta); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tn, err)
+		}
+
+		var update string
+		for _, call := range *calls {
+			if strings.HasPrefix(call, http.MethodPut+" ") && strings.Contains(call, "/users/existing-id ") {
+				update = call
+			}
+		}
+		if update == "" {
+			t.Fatalf("%s: expected the user to be updated, got calls %v", tn, *calls)
+		}
+
+		for _, s := range tc.contains {
+			if !strings.Contains(update, s) {
+				t.Errorf("%s: expected the update to contain %s, got %s", tn, s, update)
+			}
+		}
+		for _, s := range tc.excludes {
+			if strings.Contains(update, s) {
+				t.Errorf("%s: expected the update not to contain %s, got %s", tn, s, update)
+			}
+		}
+	}
+}
+
+func TestResourceUserUpdate_changePasswordAtNextLoginUpgrade(t *testing.T) {
+	for tn, configured := range map[string]map[string]interface{}{
+		"unset": {},
+		"true":  {"change_password_at_next_login": true},
+	} {
+		meta, calls := testUserServer(t)
+
+		// State written before change_password_at_next_login was tracked
+		state := &terraform.InstanceState{
+			ID: "existing-id",
+			Attributes: map[string]string{
+				"id":                 "existing-id",
+				"primary_email":      "existing@domain.ext",
+				"name.#":             "1",
+				"name.0.family_name": "Doe",
+				"name.0.given_name":  "John",
+			},
+		}
+
+		raw := map[string]interface{}{
+			"primary_email": "existing@domain.ext",
+			"name": []interface{}{
+				map[string]interface{}{
+					"family_name": "Doe",
+					"given_name":  "Jane",
+				},
+			},
+		}
+		for k, v := range configured {
+			raw[k] = v
+		}
+
+		r := resourceUser()
+		diff, err := r.Diff(state, terraform.NewResourceConfigRaw(raw), meta)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tn, err)
+		}
+		if attr, ok := diff.Attributes["change_password_at_next_login"]; ok {
+			t.Errorf("%s: expected no diff for change_password_at_next_login, got %#v", tn, attr)
+		}
+		if _, err := r.Apply(state, diff, meta); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tn, err)
+		}
+
+		for _, call := range *calls {
+			if strings.Contains(call, "changePasswordAtNextLogin") {
+				t.Errorf("%s: expected no password change to be requested, got %s", tn, call)
+			}
+		}
+	}
+}
+
+func TestResourceUserUpdate_falseBooleans(t *testing.T) {
+	meta, calls := testUserServer(t)
+
+	state := &terraform.InstanceState{
+		ID: "existing-id",
+		Attributes: map[string]string{
+			"id":                             "existing-id",
+			"primary_email":                  "existing@domain.ext",
+			"name.#":                         "1",
+			"name.0.family_name":             "Doe",
+			"name.0.given_name":              "John",
+			"change_password_at_next_login":  "true",
+			"include_in_global_address_list": "true",
+			"is_ip_whitelisted":              "true",
+			"is_suspended":                   "true",
+			"archived":                       "true",
+		},
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"primary_email": "existing@domain.ext",
+		"name": []interface{}{
+			map[string]interface{}{
+				"family_name": "Doe",
+				"given_name":  "John",
+			},
+		},
+		"change_password_at_next_login":  false,
+		"include_in_global_address_list": false,
+		"is_ip_whitelisted":              false,
+		"is_suspended":                   false,
+		"archived":                       false,
+	})
+
+	r := resourceUser()
+	diff, err := r.Diff(state, config, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := r.Apply(state, diff, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	sent := strings.Join(*calls, "\n")
+	for _, field := range []string{"changePasswordAtNextLogin", "includeInGlobalAddressList", "ipWhitelisted", "suspended", "archived"} {
+		if !strings.Contains(sent, fmt.Sprintf(`"%s":false`, field)) {
+			t.Errorf("expected %s to be sent as false, got calls %v", field, *calls)
+		}
+	}
+}
+
+func TestResourceUserUpdate_orgUnitPatch(t *testing.T) {
+	meta, calls := testUserServer(t)
+
+	state := &terraform.InstanceState{
+		ID: "existing-id",
+		Attributes: map[string]string{
+			"id":                 "existing-id",
+			"primary_email":      "existing@domain.ext",
+			"name.#":             "1",
+			"name.0.family_name": "Doe",
+			"name.0.given_name":  "John",
+			"org_unit_path":      "/",
+		},
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"primary_email": "existing@domain.ext",
+		"name": []interface{}{
+			map[string]interface{}{
+				"family_name": "Doe",
+				"given_name":  "John",
+			},
+		},
+		"org_unit_path": "Engineering",
+	})
+
+	r := resourceUser()
+	diff, err := r.Diff(state, config, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("expected the move not to recreate the user")
+	}
+	if _, err := r.Apply(state, diff, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var patches []string
+	for _, call := range *calls {
+		if strings.HasPrefix(call, http.MethodPatch+" ") {
+			patches = append(patches, call)
+		}
+		if strings.HasPrefix(call, http.MethodPut+" ") && strings.Contains(call, "orgUnitPath") {
+			t.Errorf("expected the update not to send orgUnitPath, got %s", call)
+		}
+	}
+
+	expected := http.MethodPatch + ` /admin/directory/v1/users/existing-id {"orgUnitPath":"/Engineering"}`
+	if len(patches) != 1 || strings.TrimSpace(patches[0]) != expected {
+		t.Errorf("expected only the org unit to be patched, got %v", patches)
+	}
+}
+
+// testUserAliasesServer serves existing@domain.ext with the given aliases,
+// alias inserts and deletes change them and are recorded.
+func testUserAliasesServer(t *testing.T, aliases ...string) (*Config, *[]string) {
+	var mu sync.Mutex
+	calls := []string{}
+
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/aliases"):
+			var alias directory.Alias
+			json.NewDecoder(r.Body).Decode(&alias)
+			calls = append(calls, "insert "+alias.Alias)
+			aliases = append(aliases, alias.Alias)
+			json.NewEncoder(w).Encode(alias)
+		case r.Method == http.MethodDelete && strings.Contains(r.URL.Path, "/aliases/"):
+			deleted := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			calls = append(calls, "delete "+deleted)
+			aliases = stringSliceDifference(aliases, []string{deleted})
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			json.NewEncoder(w).Encode(directory.User{
+				Id:           "existing-id",
+				PrimaryEmail: "existing@domain.ext",
+				Name:         &directory.UserName{FamilyName: "Doe", GivenName: "John"},
+				Aliases:      aliases,
+			})
+		}
+	})
+
+	return config, &calls
+}
+
+func TestResourceUserUpdate_aliases(t *testing.T) {
+	testCases := map[string]struct {
+		aliases []interface{}
+		calls   []string
+		state   []string
+	}{
+		"replaced": {[]interface{}{"new@domain.ext"}, []string{"insert new@domain.ext", "delete old@domain.ext"}, []string{"new@domain.ext"}},
+		"removed":  {[]interface{}{}, []string{"delete old@domain.ext"}, []string{}},
+	}
+
+	for tn, tc := range testCases {
+		// other@domain.ext is managed by a gsuite_user_alias
+		meta, calls := testUserAliasesServer(t, "old@domain.ext", "other@domain.ext")
+
+		state := &terraform.InstanceState{
+			ID: "existing-id",
+			Attributes: map[string]string{
+				"id":                 "existing-id",
+				"primary_email":      "existing@domain.ext",
+				"name.#":             "1",
+				"name.0.family_name": "Doe",
+				"name.0.given_name":  "John",
+				"aliases.#":          "1",
+				fmt.Sprintf("aliases.%d", hashLowercaseEmail("old@domain.ext")): "old@domain.ext",
+			},
+		}
+
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"primary_email": "existing@domain.ext",
+			"name": []interface{}{
+				map[string]interface{}{
+					"family_name": "Doe",
+					"given_name":  "John",
+				},
+			},
+			"aliases": tc.aliases,
+		})
+
+		r := resourceUser()
+		diff, err := r.Diff(state, config, meta)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tn, err)
+		}
+		newState, err := r.Apply(state, diff, meta)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tn, err)
+		}
+
+		if !reflect.DeepEqual(*calls, tc.calls) {
+			t.Errorf("%s: expected alias calls %v, got %v", tn, tc.calls, *calls)
+		}
+
+		aliases := []string{}
+		for _, alias := range r.Data(newState).Get("aliases").(*schema.Set).List() {
+			aliases = append(aliases, alias.(string))
+		}
+		if !reflect.DeepEqual(aliases, tc.state) {
+			t.Errorf("%s: expected aliases %v in state, got %v", tn, tc.state, aliases)
+		}
+	}
+}
+
+func TestResourceUserUpdate_aliasOfUserAlias(t *testing.T) {
+	meta, calls := testUserAliasesServer(t, "other@domain.ext")
+
+	r := resourceUser()
+	state := &terraform.InstanceState{
+		ID: "existing-id",
+		Attributes: map[string]string{
+			"id":                 "existing-id",
+			"primary_email":      "existing@domain.ext",
+			"name.#":             "1",
+			"name.0.family_name": "Doe",
+			"name.0.given_name":  "John",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"primary_email": "existing@domain.ext",
+		"name": []interface{}{
+			map[string]interface{}{
+				"family_name": "Doe",
+				"given_name":  "John",
+			},
+		},
+		"aliases": []interface{}{"other@domain.ext"},
+	})
+
+	diff, err := r.Diff(state, config, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = r.Apply(state, diff, meta)
+	if err == nil || !strings.Contains(err.Error(), "gsuite_user_alias") {
+		t.Fatalf("expected an error about gsuite_user_alias, got %v", err)
+	}
+	if len(*calls) > 0 {
+		t.Fatalf("expected no alias to be changed, got %v", *calls)
+	}
+}
@@ -0,0 +1,262 @@
+package gsuite
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// userPhotoMimeTypes are the image formats the API accepts as user photo.
+var userPhotoMimeTypes = []string{"image/bmp", "image/gif", "image/jpeg", "image/png"}
+
+func resourceUserThumbnailPhoto() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserThumbnailPhotoCreate,
+		Read:   resourceUserThumbnailPhotoRead,
+		Update: resourceUserThumbnailPhotoUpdate,
+		Delete: resourceUserThumbnailPhotoDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceUserThumbnailPhotoImporter,
+		},
+
+		CustomizeDiff: resourceUserThumbnailPhotoCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				StateFunc:        lowercaseEmail,
+				DiffSuppressFunc: emailDiffSuppress,
+				ValidateFunc:     validateEmail,
+			},
+
+			// Either the base64 encoded photo or the path to the photo
+			"photo_data": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"photo_path"},
+			},
+
+			"photo_path": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"photo_data"},
+			},
+
+			// Changes to the file behind photo_path are detected through the
+			// checksum, the API resizes and re-encodes the uploaded photo
+			"photo_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"width": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"height": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"mime_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// decodeUserPhoto decodes a base64 encoded photo, the standard as well as the
+// web-safe alphabet are accepted, with or without padding.
+func decodeUserPhoto(data string) ([]byte, error) {
+	data = strings.Join(strings.Fields(data), "")
+
+	var err error
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		var photo []byte
+		if photo, err = encoding.DecodeString(data); err == nil {
+			return photo, nil
+		}
+	}
+
+	return nil, fmt.Errorf("photo_data is not base64 encoded: %s", err)
+}
+
+// userPhoto returns the photo configured in either photo_data or photo_path.
+func userPhoto(data, path string) ([]byte, error) {
+	if path != "" {
+		photo, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading photo_path: %s", err)
+		}
+		return photo, nil
+	}
+
+	if data == "" {
+		return nil, fmt.Errorf("One of photo_data or photo_path must be set")
+	}
+	return decodeUserPhoto(data)
+}
+
+// userPhotoMimeType detects the format of a photo, returning an error when the
+// API does not support it.
+func userPhotoMimeType(photo []byte) (string, error) {
+	mimeType := http.DetectContentType(photo)
+	for _, supported := range userPhotoMimeTypes {
+		if mimeType == supported {
+			return mimeType, nil
+		}
+	}
+
+	return "", fmt.Errorf("Unsupported photo format %s, expected one of %s", mimeType, strings.Join(userPhotoMimeTypes, ", "))
+}
+
+func userPhotoSHA256(photo []byte) string {
+	sum := sha256.Sum256(photo)
+	return hex.EncodeToString(sum[:])
+}
+
+// resourceUserThumbnailPhotoCustomizeDiff validates the photo before it is
+// uploaded and plans an update when its contents change.
+func resourceUserThumbnailPhotoCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("photo_data") || !d.NewValueKnown("photo_path") {
+		return nil
+	}
+
+	photo, err := userPhoto(d.Get("photo_data").(string), d.Get("photo_path").(string))
+	if err != nil {
+		return err
+	}
+	if _, err := userPhotoMimeType(photo); err != nil {
+		return err
+	}
+
+	if sum := userPhotoSHA256(photo); sum != d.Get("photo_sha256").(string) {
+		return d.SetNew("photo_sha256", sum)
+	}
+	return nil
+}
+
+func userThumbnailPhotoUpdate(d *schema.ResourceData, config *Config) error {
+	primaryEmail := strings.ToLower(d.Get("primary_email").(string))
+
+	photo, err := userPhoto(d.Get("photo_data").(string), d.Get("photo_path").(string))
+	if err != nil {
+		return err
+	}
+	mimeType, err := userPhotoMimeType(photo)
+	if err != nil {
+		return err
+	}
+
+	// The API only accepts the web-safe base64 alphabet
+	thumbnail := &directory.UserPhoto{
+		MimeType:  mimeType,
+		PhotoData: base64.URLEncoding.EncodeToString(photo),
+	}
+
+	err = retry(func() error {
+		_, err = config.directory.Users.Photos.Update(primaryEmail, thumbnail).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating user photo: %s", err)
+	}
+
+	d.Set("photo_sha256", userPhotoSHA256(photo))
+	log.Printf("[INFO] Updated photo of user: %s", primaryEmail)
+	return nil
+}
+
+func resourceUserThumbnailPhotoCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if err := userThumbnailPhotoUpdate(d, config); err != nil {
+		return err
+	}
+
+	d.SetId(strings.ToLower(d.Get("primary_email").(string)))
+	return resourceUserThumbnailPhotoRead(d, meta)
+}
+
+func resourceUserThumbnailPhotoUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if err := userThumbnailPhotoUpdate(d, config); err != nil {
+		return err
+	}
+
+	return resourceUserThumbnailPhotoRead(d, meta)
+}
+
+func resourceUserThumbnailPhotoRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var thumbnail *directory.UserPhoto
+	var err error
+	err = retry(func() error {
+		thumbnail, err = config.directory.Users.Photos.Get(d.Id()).Fields("width", "height", "mimeType", "etag").Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	// A 404 means the photo, or the user together with its photo, is gone
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("User photo of %q", d.Id()))
+	}
+
+	d.Set("primary_email", d.Id())
+	d.Set("width", thumbnail.Width)
+	d.Set("height", thumbnail.Height)
+	d.Set("mime_type", thumbnail.MimeType)
+	d.Set("etag", thumbnail.Etag)
+
+	return nil
+}
+
+func resourceUserThumbnailPhotoDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var err error
+	err = retry(func() error {
+		err = config.directory.Users.Photos.Delete(d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] User photo of %q is already gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error deleting user photo: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using the primary email of the user, the photo itself is not
+// imported
+func resourceUserThumbnailPhotoImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(strings.ToLower(d.Id()))
+	d.Set("primary_email", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,112 @@
+package gsuite
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+const testUserPhotoPath = "test-fixtures/photo.png"
+
+func TestDecodeUserPhoto(t *testing.T) {
+	// Encodes to both '+' and '/' in the standard alphabet
+	photo := []byte{0xfb, 0xff, 0xbf, 0x01}
+
+	for _, data := range []string{
+		base64.StdEncoding.EncodeToString(photo),
+		base64.URLEncoding.EncodeToString(photo),
+		base64.RawStdEncoding.EncodeToString(photo),
+		base64.RawURLEncoding.EncodeToString(photo),
+		"+/+/\nAQ==\n",
+	} {
+		decoded, err := decodeUserPhoto(data)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", data, err)
+			continue
+		}
+		if !bytes.Equal(decoded, photo) {
+			t.Errorf("expected %q to be decoded to %v, got %v", data, photo, decoded)
+		}
+	}
+
+	if _, err := decodeUserPhoto("not base64!"); err == nil {
+		t.Errorf("expected an error for data which is not base64 encoded")
+	}
+}
+
+func TestUserPhotoMimeType(t *testing.T) {
+	photo, err := ioutil.ReadFile(testUserPhotoPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if mimeType, err := userPhotoMimeType(photo); err != nil || mimeType != "image/png" {
+		t.Errorf("expected the fixture to be detected as image/png, got %q and %v", mimeType, err)
+	}
+	if _, err := userPhotoMimeType([]byte("hello world")); err == nil {
+		t.Errorf("expected an error for a photo which is not an image")
+	}
+}
+
+func TestResourceUserThumbnailPhotoCreate(t *testing.T) {
+	photo, err := ioutil.ReadFile(testUserPhotoPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var uploaded *directory.UserPhoto
+	config := testAPIConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/users/jdoe@domain.ext/photos/thumbnail") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPut:
+			uploaded = &directory.UserPhoto{}
+			if err := json.NewDecoder(r.Body).Decode(uploaded); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			fmt.Fprint(w, `{"width":96,"height":96,"mimeType":"image/jpeg","etag":"\"photo-etag\""}`)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceUserThumbnailPhoto().Schema, map[string]interface{}{
+		"primary_email": "JDoe@domain.ext",
+		"photo_path":    testUserPhotoPath,
+	})
+	if err := resourceUserThumbnailPhotoCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if uploaded == nil {
+		t.Fatalf("expected the photo to be uploaded")
+	}
+	if uploaded.MimeType != "image/png" {
+		t.Errorf("expected the photo to be uploaded as image/png, got %q", uploaded.MimeType)
+	}
+	// The API only accepts the web-safe alphabet
+	if sent, err := base64.URLEncoding.DecodeString(uploaded.PhotoData); err != nil || !bytes.Equal(sent, photo) {
+		t.Errorf("expected the photo to be sent web-safe base64 encoded, got %q", uploaded.PhotoData)
+	}
+
+	if d.Id() != "jdoe@domain.ext" {
+		t.Errorf("expected the primary email as ID, got %q", d.Id())
+	}
+	if d.Get("width").(int) != 96 || d.Get("height").(int) != 96 || d.Get("mime_type").(string) != "image/jpeg" {
+		t.Errorf("expected the photo metadata to be read, got %v", d.State().Attributes)
+	}
+	if d.Get("photo_sha256").(string) != userPhotoSHA256(photo) {
+		t.Errorf("expected the checksum of the photo to be stored")
+	}
+}
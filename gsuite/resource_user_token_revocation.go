@@ -0,0 +1,91 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Revoking the token a user issued to an application is a one-off action,
+// destroying the resource does not restore the token. When the user grants the
+// application access again, the token is revoked again on the next apply.
+func resourceUserTokenRevocation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserTokenRevocationCreate,
+		Read:   resourceUserTokenRevocationRead,
+		Delete: resourceUserTokenRevocationDelete,
+
+		Schema: map[string]*schema.Schema{
+			// The user, by email, alias or id
+			"user_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceUserTokenRevocationCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userKey := strings.ToLower(d.Get("user_key").(string))
+	clientID := d.Get("client_id").(string)
+
+	var err error
+	err = retry(func() error {
+		err = config.directory.Tokens.Delete(userKey, clientID).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	// A token which is already gone is revoked just as well
+	if isNotFound(err) {
+		log.Printf("[WARN] Token of user %s for client %s is already revoked", userKey, clientID)
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error revoking token of user %s for client %s: %s", userKey, clientID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", userKey, clientID))
+	log.Printf("[INFO] Revoked token of user %s for client %s", userKey, clientID)
+	return nil
+}
+
+func resourceUserTokenRevocationRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	userKey := strings.ToLower(d.Get("user_key").(string))
+	clientID := d.Get("client_id").(string)
+
+	var err error
+	err = retry(func() error {
+		_, err = config.directory.Tokens.Get(userKey, clientID).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error reading token of user %s for client %s: %s", userKey, clientID, err)
+	}
+
+	log.Printf("[WARN] Token of user %s for client %s was issued again, removing the revocation from state to revoke it again", userKey, clientID)
+	d.SetId("")
+	return nil
+}
+
+func resourceUserTokenRevocationDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] The revocation of token %s can't be undone, removing it from state only", d.Id())
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,65 @@
+package gsuite
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestResourceUserTokenRevocation(t *testing.T) {
+	config, tokens, _ := testTokensServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceUserTokenRevocation().Schema, map[string]interface{}{
+		"user_key":  "jane@domain.ext",
+		"client_id": "1234.apps.googleusercontent.com",
+	})
+	if err := resourceUserTokenRevocationCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d.Id() != "jane@domain.ext/1234.apps.googleusercontent.com" || tokens["1234.apps.googleusercontent.com"] {
+		t.Fatalf("expected the token to be revoked, got id %q and tokens %v", d.Id(), tokens)
+	}
+
+	// Revoking a token which is already gone succeeds
+	if err := resourceUserTokenRevocationCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := resourceUserTokenRevocationRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the revocation to stay in state while the token is gone")
+	}
+
+	// A token issued again is revoked again on the next apply
+	tokens["1234.apps.googleusercontent.com"] = true
+	if err := resourceUserTokenRevocationRead(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatal("expected the revocation to be removed from state once the token is issued again")
+	}
+}
+
+func TestResourceUserAspRevocation(t *testing.T) {
+	config, _, asps := testTokensServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceUserAspRevocation().Schema, map[string]interface{}{
+		"user_key": "jane@domain.ext",
+		"code_id":  42,
+	})
+	if err := resourceUserAspRevocationCreate(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d.Id() != "jane@domain.ext/42" || asps["42"] {
+		t.Fatalf("expected the application-specific password to be revoked, got id %q and asps %v", d.Id(), asps)
+	}
+
+	if err := resourceUserAspRevocationDelete(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatal("expected the revocation to be removed from state")
+	}
+}
@@ -0,0 +1,116 @@
+package gsuite
+
+import (
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig controls the retries performed by the http transport for
+// rate limited and failed requests.
+type RetryConfig struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// retryTransport retries idempotent requests which are answered with a
+// rate limit or server error, using a jittered exponential backoff.
+type retryTransport struct {
+	config RetryConfig
+	next   http.RoundTripper
+}
+
+func newRetryTransport(config RetryConfig, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{
+		config: config,
+		next:   next,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.config.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || attempt >= t.config.MaxRetries || !isRetryableStatus(resp.StatusCode) || !isIdempotentRequest(req) {
+			return resp, err
+		}
+
+		wait := retryAfter(resp)
+		if wait == 0 {
+			wait = backoff
+			if backoff > 0 {
+				wait += time.Duration(rand.Int63n(int64(backoff)/2 + 1))
+			}
+		}
+		if t.config.MaxBackoff > 0 && wait > t.config.MaxBackoff {
+			wait = t.config.MaxBackoff
+		}
+
+		// The body was not consumed by the caller, so throw it away before retrying
+		resp.Body.Close()
+
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		log.Printf("[DEBUG] Retrying %s %s after status %d in %s", req.Method, req.URL, resp.StatusCode, wait)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		backoff = backoff * 2
+	}
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Only requests that can't cause duplicate side effects are retried, a
+// request body also needs to be replayable.
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	}
+	return false
+}
+
+// retryAfter returns how long the API asked us to wait, the Retry-After header
+// holds either a number of seconds or a HTTP date.
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
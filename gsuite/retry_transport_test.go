@@ -0,0 +1,130 @@
+package gsuite
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRetryServer(statuses ...int) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := http.StatusOK
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	return server, &calls
+}
+
+func testRetryClient(maxRetries int) *http.Client {
+	return &http.Client{
+		Transport: newRetryTransport(RetryConfig{
+			MaxRetries:     maxRetries,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     10 * time.Millisecond,
+		}, nil),
+	}
+}
+
+func TestRetryTransport_rateLimited(t *testing.T) {
+	server, calls := testRetryServer(http.StatusTooManyRequests, http.StatusTooManyRequests)
+	defer server.Close()
+
+	resp, err := testRetryClient(3).Get(server.URL)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if *calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", *calls)
+	}
+}
+
+func TestRetryTransport_putWithBody(t *testing.T) {
+	var bodies []string
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"name":"test"}`))
+	resp, err := testRetryClient(3).Do(req)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"name":"test"}` {
+		t.Fatalf("expected the body to be sent twice, got %v", bodies)
+	}
+}
+
+func TestRetryTransport_maxRetries(t *testing.T) {
+	server, calls := testRetryServer(http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests)
+	defer server.Close()
+
+	resp, err := testRetryClient(1).Get(server.URL)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", resp.StatusCode)
+	}
+	if *calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", *calls)
+	}
+}
+
+func TestRetryTransport_nonRetryable(t *testing.T) {
+	for _, status := range []int{http.StatusForbidden, http.StatusNotFound} {
+		server, calls := testRetryServer(status)
+
+		resp, err := testRetryClient(3).Get(server.URL)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		if resp.StatusCode != status {
+			t.Fatalf("expected status %d, got %d", status, resp.StatusCode)
+		}
+		if *calls != 1 {
+			t.Fatalf("expected a single call for status %d, got %d", status, *calls)
+		}
+		server.Close()
+	}
+}
+
+func TestRetryTransport_nonIdempotent(t *testing.T) {
+	server, calls := testRetryServer(http.StatusTooManyRequests)
+	defer server.Close()
+
+	resp, err := testRetryClient(3).Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", resp.StatusCode)
+	}
+	if *calls != 1 {
+		t.Fatalf("expected a single call, got %d", *calls)
+	}
+}
@@ -0,0 +1,166 @@
+package gsuite
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+	reports "google.golang.org/api/admin/reports/v1"
+	gmail "google.golang.org/api/gmail/v1"
+	groupSettings "google.golang.org/api/groupssettings/v1"
+)
+
+// resourceScopes lists, per resource type, the oauth scopes that grant access
+// to the APIs it calls. One of them needs to be configured on the provider.
+var resourceScopes = map[string][]string{
+	"gsuite_building":              {directory.AdminDirectoryResourceCalendarScope},
+	"gsuite_calendar_resource":     {directory.AdminDirectoryResourceCalendarScope},
+	"gsuite_chrome_device":         {directory.AdminDirectoryDeviceChromeosScope},
+	"gsuite_customer":              {directory.AdminDirectoryCustomerScope},
+	"gsuite_domain":                {directory.AdminDirectoryDomainScope},
+	"gsuite_domain_alias":          {directory.AdminDirectoryDomainScope},
+	"gsuite_feature":               {directory.AdminDirectoryResourceCalendarScope},
+	"gsuite_group":                 {directory.AdminDirectoryGroupScope},
+	"gsuite_group_alias":           {directory.AdminDirectoryGroupScope},
+	"gsuite_group_member":          {directory.AdminDirectoryGroupScope, directory.AdminDirectoryGroupMemberScope},
+	"gsuite_group_members":         {directory.AdminDirectoryGroupScope, directory.AdminDirectoryGroupMemberScope},
+	"gsuite_group_settings":        {groupSettings.AppsGroupsSettingsScope},
+	"gsuite_mobile_device_action":  {directory.AdminDirectoryDeviceMobileScope, directory.AdminDirectoryDeviceMobileActionScope},
+	"gsuite_org_unit":              {directory.AdminDirectoryOrgunitScope},
+	"gsuite_role":                  {directory.AdminDirectoryRolemanagementScope},
+	"gsuite_role_assignment":       {directory.AdminDirectoryRolemanagementScope},
+	"gsuite_user":                  {directory.AdminDirectoryUserScope},
+	"gsuite_user_alias":            {directory.AdminDirectoryUserScope, directory.AdminDirectoryUserAliasScope},
+	"gsuite_user_asp_revocation":   {directory.AdminDirectoryUserSecurityScope},
+	"gsuite_user_attributes":       {directory.AdminDirectoryUserScope},
+	"gsuite_user_gmail_sendas":     {gmail.GmailSettingsSharingScope},
+	"gsuite_user_make_admin":       {directory.AdminDirectoryUserScope},
+	"gsuite_user_schema":           {directory.AdminDirectoryUserschemaScope},
+	"gsuite_user_thumbnail_photo":  {directory.AdminDirectoryUserScope},
+	"gsuite_user_token_revocation": {directory.AdminDirectoryUserSecurityScope},
+}
+
+// dataSourceScopes lists the oauth scopes per data source, read-only scopes
+// are sufficient. Data sources which don't call any API, or check their scopes
+// themselves, have no entry.
+var dataSourceScopes = map[string][]string{
+	"gsuite_buildings": {
+		directory.AdminDirectoryResourceCalendarScope,
+		directory.AdminDirectoryResourceCalendarReadonlyScope,
+	},
+	"gsuite_calendar_resource": {
+		directory.AdminDirectoryResourceCalendarScope,
+		directory.AdminDirectoryResourceCalendarReadonlyScope,
+	},
+	"gsuite_calendar_resources": {
+		directory.AdminDirectoryResourceCalendarScope,
+		directory.AdminDirectoryResourceCalendarReadonlyScope,
+	},
+	"gsuite_customer": {
+		directory.AdminDirectoryCustomerScope,
+		directory.AdminDirectoryCustomerReadonlyScope,
+	},
+	"gsuite_group": {
+		directory.AdminDirectoryGroupScope,
+		directory.AdminDirectoryGroupReadonlyScope,
+	},
+	"gsuite_group_members": {
+		directory.AdminDirectoryGroupScope,
+		directory.AdminDirectoryGroupReadonlyScope,
+		directory.AdminDirectoryGroupMemberScope,
+		directory.AdminDirectoryGroupMemberReadonlyScope,
+	},
+	"gsuite_group_settings": {groupSettings.AppsGroupsSettingsScope},
+	"gsuite_groups": {
+		directory.AdminDirectoryGroupScope,
+		directory.AdminDirectoryGroupReadonlyScope,
+	},
+	"gsuite_login_activities": {reports.AdminReportsAuditReadonlyScope},
+	"gsuite_mobile_devices": {
+		directory.AdminDirectoryDeviceMobileScope,
+		directory.AdminDirectoryDeviceMobileReadonlyScope,
+	},
+	"gsuite_org_unit": {
+		directory.AdminDirectoryOrgunitScope,
+		directory.AdminDirectoryOrgunitReadonlyScope,
+	},
+	"gsuite_org_units": {
+		directory.AdminDirectoryOrgunitScope,
+		directory.AdminDirectoryOrgunitReadonlyScope,
+	},
+	"gsuite_privileges": {
+		directory.AdminDirectoryRolemanagementScope,
+		directory.AdminDirectoryRolemanagementReadonlyScope,
+	},
+	"gsuite_role_assignments": {
+		directory.AdminDirectoryRolemanagementScope,
+		directory.AdminDirectoryRolemanagementReadonlyScope,
+	},
+	"gsuite_roles": {
+		directory.AdminDirectoryRolemanagementScope,
+		directory.AdminDirectoryRolemanagementReadonlyScope,
+	},
+	"gsuite_user": {
+		directory.AdminDirectoryUserScope,
+		directory.AdminDirectoryUserReadonlyScope,
+	},
+	"gsuite_user_asps": {
+		directory.AdminDirectoryUserSecurityScope,
+	},
+	"gsuite_user_schema": {
+		directory.AdminDirectoryUserschemaScope,
+		directory.AdminDirectoryUserschemaReadonlyScope,
+	},
+	"gsuite_user_tokens": {
+		directory.AdminDirectoryUserSecurityScope,
+	},
+	"gsuite_users": {
+		directory.AdminDirectoryUserScope,
+		directory.AdminDirectoryUserReadonlyScope,
+	},
+	"gsuite_users_without_2sv": {
+		directory.AdminDirectoryUserScope,
+		directory.AdminDirectoryUserReadonlyScope,
+	},
+}
+
+// withScopeCheck makes every operation of the resource fail with an error
+// naming the missing scope before any API call is made, rather than with the
+// 403 returned by the API.
+func withScopeCheck(name string, r *schema.Resource, scopes []string) {
+	if len(scopes) == 0 {
+		return
+	}
+
+	check := func(meta interface{}) error {
+		config := meta.(*Config)
+		if err := config.requireScope(name, scopes...); err != nil {
+			return err
+		}
+		return config.requireDelegation(name, scopes...)
+	}
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			if err := check(meta); err != nil {
+				return err
+			}
+			return f(d, meta)
+		}
+	}
+
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.Delete = wrap(r.Delete)
+
+	if r.Importer != nil && r.Importer.State != nil {
+		state := r.Importer.State
+		r.Importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			if err := check(meta); err != nil {
+				return nil, err
+			}
+			return state(d, meta)
+		}
+	}
+}
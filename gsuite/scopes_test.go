@@ -0,0 +1,72 @@
+package gsuite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+	groupSettings "google.golang.org/api/groupssettings/v1"
+)
+
+func TestConfigRequireScope(t *testing.T) {
+	config := &Config{OauthScopes: []string{directory.AdminDirectoryGroupScope}}
+
+	if err := config.requireScope("gsuite_group", directory.AdminDirectoryGroupScope); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := config.requireScope("gsuite_group_member", directory.AdminDirectoryGroupMemberScope, directory.AdminDirectoryGroupScope); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err := config.requireScope("gsuite_group_settings", groupSettings.AppsGroupsSettingsScope)
+	if err == nil {
+		t.Fatal("expected an error for a missing scope")
+	}
+	expected := `gsuite_group_settings requires the "https://www.googleapis.com/auth/apps.groups.settings" oauth scope`
+	if !strings.Contains(err.Error(), expected) {
+		t.Fatalf("expected error to contain %q, got %q", expected, err)
+	}
+}
+
+func TestWithScopeCheck(t *testing.T) {
+	called := false
+	r := &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			called = true
+			return nil
+		},
+	}
+	withScopeCheck("gsuite_test", r, []string{directory.AdminDirectoryDomainScope})
+
+	err := r.Read(nil, &Config{OauthScopes: defaultOauthScopes})
+	if err == nil || !strings.Contains(err.Error(), directory.AdminDirectoryDomainScope) {
+		t.Fatalf("expected an error naming the missing scope, got %v", err)
+	}
+	if called {
+		t.Fatal("expected the read not to be called without the scope")
+	}
+
+	if err := r.Read(nil, &Config{OauthScopes: []string{directory.AdminDirectoryDomainScope}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !called {
+		t.Fatal("expected the read to be called with the scope")
+	}
+}
+
+func TestProviderResourceScopes(t *testing.T) {
+	p := Provider()
+	for name := range p.ResourcesMap {
+		if len(resourceScopes[name]) == 0 {
+			t.Errorf("no oauth scopes defined for resource %s", name)
+		}
+	}
+}
+
+func TestDefaultOauthScopes_groupSettings(t *testing.T) {
+	config := &Config{OauthScopes: defaultOauthScopes}
+	if err := config.requireScope("gsuite_group_settings", resourceScopes["gsuite_group_settings"]...); err != nil {
+		t.Fatalf("expected the default scopes to cover group settings: %s", err)
+	}
+}
@@ -0,0 +1,83 @@
+package gsuite
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// timeoutTransport bounds the time of every request, and optionally of all
+// requests made during an operation, so a hanging API call can't block an
+// apply indefinitely.
+type timeoutTransport struct {
+	// timeout applies to every single request
+	timeout time.Duration
+	// deadline applies to all requests, operation is used in the error
+	deadline  time.Time
+	operation string
+	next      http.RoundTripper
+}
+
+func newTimeoutTransport(timeout time.Duration, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &timeoutTransport{
+		timeout: timeout,
+		next:    next,
+	}
+}
+
+func newDeadlineTransport(operation string, deadline time.Time, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &timeoutTransport{
+		deadline:  deadline,
+		operation: operation,
+		next:      next,
+	}
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+	} else if !t.deadline.IsZero() {
+		ctx, cancel = context.WithDeadline(ctx, t.deadline)
+	}
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		// Only report our own timeout, not a cancellation by the caller
+		if ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+			return nil, t.timeoutError(req)
+		}
+		return nil, err
+	}
+
+	// The context needs to stay alive until the body has been read
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+func (t *timeoutTransport) timeoutError(req *http.Request) error {
+	if t.operation != "" {
+		return fmt.Errorf("%s timed out while calling %s %s", t.operation, req.Method, req.URL)
+	}
+	return fmt.Errorf("%s %s timed out after %s", req.Method, req.URL, t.timeout)
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,68 @@
+package gsuite
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSlowServer(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+}
+
+func TestTimeoutTransport_requestTimeout(t *testing.T) {
+	server := testSlowServer(time.Second)
+	defer server.Close()
+
+	client := &http.Client{Transport: newTimeoutTransport(50*time.Millisecond, nil)}
+	_, err := client.Get(server.URL)
+	if err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if !strings.Contains(err.Error(), "timed out after 50ms") {
+		t.Fatalf("expected a timeout error, got %s", err)
+	}
+}
+
+func TestTimeoutTransport_operationDeadline(t *testing.T) {
+	server := testSlowServer(time.Second)
+	defer server.Close()
+
+	transport := newDeadlineTransport("Creating gsuite_group_members", time.Now().Add(50*time.Millisecond), nil)
+	client := &http.Client{Transport: transport}
+	_, err := client.Get(server.URL)
+	if err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if !strings.Contains(err.Error(), "Creating gsuite_group_members timed out") {
+		t.Fatalf("expected the error to name the operation, got %s", err)
+	}
+}
+
+func TestTimeoutTransport_fastResponse(t *testing.T) {
+	server := testSlowServer(0)
+	defer server.Close()
+
+	client := &http.Client{Transport: newTimeoutTransport(time.Second, nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	// The body must still be readable after RoundTrip returned
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil || string(body) != "ok" {
+		t.Fatalf("expected body ok, got %q (%v)", body, err)
+	}
+}
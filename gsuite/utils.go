@@ -3,20 +3,46 @@
 package gsuite
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
 	"net/mail"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"golang.org/x/crypto/ssh"
+	directory "google.golang.org/api/admin/directory/v1"
 	"google.golang.org/api/googleapi"
 )
 
+// isNotFound reports whether err is the API reporting that an entity does not
+// exist, by status code or by reason.
+func isNotFound(err error) bool {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false
+	}
+	if gerr.Code == 404 {
+		return true
+	}
+	for _, e := range gerr.Errors {
+		if e.Reason == "notFound" {
+			return true
+		}
+	}
+	return false
+}
+
+// handleNotFoundError removes a resource which is gone from the state, so it
+// is created again, and returns any other error.
 func handleNotFoundError(err error, d *schema.ResourceData, resource string) error {
-	if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+	if isNotFound(err) {
 		log.Printf("[WARN] Removing %s because it's gone", resource)
 		// The resource doesn't exist anymore
 		d.SetId("")
@@ -27,6 +53,23 @@ func handleNotFoundError(err error, d *schema.ResourceData, resource string) err
 	return fmt.Errorf("Error reading %s: %s", resource, err)
 }
 
+// isDuplicateError reports whether err is the API refusing to create an
+// entity which already exists.
+func isDuplicateError(err error) bool {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		if gerr.Code == 409 {
+			return true
+		}
+		for _, e := range gerr.Errors {
+			if e.Reason == "duplicate" {
+				return true
+			}
+		}
+	}
+	return strings.Contains(fmt.Sprintf("%s", err), "Entity already exists.")
+}
+
 func retry(retryFunc func() error, minutes int) error {
 	return retryTime(retryFunc, minutes, false, false, false)
 }
@@ -138,6 +181,109 @@ func retryTime(retryFunc func() error, minutes int, retryNotFound bool, retryPas
 	})
 }
 
+// readAfterWriteAttempts and readAfterWriteBackoff bound how long a read right
+// after a write waits for the written entity to propagate, a few seconds.
+var (
+	readAfterWriteAttempts = 5
+	readAfterWriteBackoff  = 500 * time.Millisecond
+)
+
+// retryReadAfterWrite retries the read of an entity which was just written
+// while the API still reports it as not found. Other errors are returned
+// straight away, readFunc deals with those through the general retry layer.
+func retryReadAfterWrite(readFunc func() error) error {
+	wait := readAfterWriteBackoff
+	for attempt := 1; ; attempt++ {
+		err := readFunc()
+		if !isNotFound(err) || attempt >= readAfterWriteAttempts {
+			return err
+		}
+
+		log.Printf("[DEBUG] Retrying read after write for eventual consistency in %s...", wait)
+		time.Sleep(wait)
+		wait = wait * 2
+	}
+}
+
+// paginate calls listPage with the token of every page of a list call in
+// turn, starting with the first page, until listPage returns no next page
+// token. listPage handles the results of its page before returning.
+func paginate(listPage func(token string) (string, error)) error {
+	token := ""
+	for {
+		next, err := listPage(token)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		// Don't loop forever on a broken API response
+		if next == token {
+			return fmt.Errorf("[ERROR] The API returned page token %q again", token)
+		}
+		token = next
+	}
+}
+
+// impersonatedConfig returns the config of a resource, acting on behalf of the
+// impersonated_user_email of the resource when it is set.
+func impersonatedConfig(d *schema.ResourceData, meta interface{}) (*Config, error) {
+	config := meta.(*Config)
+	if v, ok := d.GetOk("impersonated_user_email"); ok {
+		return config.impersonating(v.(string))
+	}
+	return config, nil
+}
+
+// userReadProjections are the projections users can be read with, custom
+// schema values are only returned with the full projection.
+var userReadProjections = []string{"basic", "full"}
+
+// userReadViewTypes are the views users can be read with, admin-only fields
+// are only returned in the admin view.
+var userReadViewTypes = []string{"admin_view", "domain_public"}
+
+// schemaUserRead lets a resource override the projection and view type of
+// the provider it reads users with.
+var schemaUserRead = map[string]*schema.Schema{
+	"read_projection": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(userReadProjections, false),
+	},
+
+	"read_view_type": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(userReadViewTypes, false),
+	},
+}
+
+// getUser returns the call reading a user with the projection and view type
+// of the resource, or of the provider when the resource doesn't set them.
+// Imports pass a nil d, they always use the provider's.
+func getUser(d *schema.ResourceData, config *Config, userKey string) *directory.UsersGetCall {
+	projection, viewType := config.ReadProjection, config.ReadViewType
+	if d != nil {
+		if v, ok := d.GetOk("read_projection"); ok {
+			projection = v.(string)
+		}
+		if v, ok := d.GetOk("read_view_type"); ok {
+			viewType = v.(string)
+		}
+	}
+
+	call := config.directory.Users.Get(userKey)
+	if projection != "" {
+		call = call.Projection(projection)
+	}
+	if viewType != "" {
+		call = call.ViewType(viewType)
+	}
+	return call
+}
+
 func mergeSchemas(a, b map[string]*schema.Schema) map[string]*schema.Schema {
 	merged := make(map[string]*schema.Schema)
 
@@ -160,6 +306,14 @@ func convertStringSet(set *schema.Set) []string {
 	return s
 }
 
+func convertStringArray(list []interface{}) []string {
+	s := make([]string, 0, len(list))
+	for _, v := range list {
+		s = append(s, v.(string))
+	}
+	return s
+}
+
 func stringSliceDifference(left []string, right []string) []string {
 	var d []string
 	for _, l := range left {
@@ -177,6 +331,49 @@ func stringSliceDifference(left []string, right []string) []string {
 	return d
 }
 
+// checkEmailDomain fails the plan of a new or changed email whose domain is not
+// a domain of the customer, likely a typo, or is not verified yet, both of
+// which the API refuses with a generic error. Listing the domains requires the
+// admin.directory.domain.readonly scope, when the domains can't be listed the
+// check is skipped.
+func checkEmailDomain(d *schema.ResourceDiff, key string, config *Config) error {
+	if config.SkipDomainCheck || !d.NewValueKnown(key) {
+		return nil
+	}
+
+	// Emails which only differ in case are the same
+	oldEmail, newEmail := d.GetChange(key)
+	if strings.EqualFold(oldEmail.(string), newEmail.(string)) {
+		return nil
+	}
+
+	email := strings.ToLower(newEmail.(string))
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return nil
+	}
+	domain := email[at+1:]
+
+	domains, err := config.customerDomains()
+	if err != nil {
+		log.Printf("[WARN] Unable to check the domain of %s: %s", email, err)
+		return nil
+	}
+	verified, err := config.customerVerifiedDomains()
+	if err != nil {
+		log.Printf("[WARN] Unable to check the domain of %s: %s", email, err)
+		return nil
+	}
+
+	if !domains[domain] {
+		return fmt.Errorf("[ERROR] The domain of %s %s is not a domain of the customer, check it for typos or set skip_domain_check in the provider when the domain is added in the same run", key, email)
+	}
+	if !verified[domain] {
+		return fmt.Errorf("[ERROR] The domain of %s %s is not verified yet, verify it in the admin console or set skip_domain_check in the provider", key, email)
+	}
+	return nil
+}
+
 func validateEmail(v interface{}, k string) (warnings []string, errors []error) {
 	if v == nil || v.(string) == "" {
 		return
@@ -204,3 +401,75 @@ func validateEmail(v interface{}, k string) (warnings []string, errors []error)
 
 	return
 }
+
+// lowercaseEmail stores email addresses the way Google returns them.
+func lowercaseEmail(val interface{}) string {
+	return strings.ToLower(val.(string))
+}
+
+// emailDiffSuppress compares email addresses case-insensitively, Google
+// lowercases them.
+func emailDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(strings.Trim(old, `"`), strings.Trim(new, `"`))
+}
+
+// hashLowercaseEmail hashes the email addresses of a set regardless of case,
+// so a differently cased address is not seen as a new element.
+func hashLowercaseEmail(v interface{}) int {
+	return hashcode.String(strings.ToLower(v.(string)))
+}
+
+var phoneE164Regexp = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// validatePhoneE164 checks that a phone number is in E.164 format, e.g.
+// +31201234567.
+func validatePhoneE164(v interface{}, k string) (warnings []string, errors []error) {
+	if v == nil || v.(string) == "" {
+		return
+	}
+	phone := v.(string)
+
+	if !phoneE164Regexp.MatchString(phone) {
+		errors = append(errors,
+			fmt.Errorf("%s: phone number %s is not in E.164 format, expected a format of +31201234567", k, phone))
+	}
+
+	return
+}
+
+var languageCodeRegexp = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z]{4})?(-([A-Za-z]{2}|[0-9]{3}))?(-([A-Za-z0-9]{5,8}|[0-9][A-Za-z0-9]{3}))*$`)
+
+// validateLanguageCode checks that a language is a BCP 47 language tag of a
+// language with optional script, region and variants, e.g. en, en-GB or
+// zh-Hant-TW.
+func validateLanguageCode(v interface{}, k string) (warnings []string, errors []error) {
+	if v == nil || v.(string) == "" {
+		return
+	}
+	language := v.(string)
+
+	if !languageCodeRegexp.MatchString(language) {
+		errors = append(errors,
+			fmt.Errorf("%s: %s is not a BCP 47 language code, expected a format of en or en-GB", k, language))
+	}
+
+	return
+}
+
+// validateSSHPublicKey checks that a key is an OpenSSH public key in the
+// authorized_keys format, e.g. "ssh-ed25519 AAAA... user@host".
+func validateSSHPublicKey(v interface{}, k string) (warnings []string, errors []error) {
+	if v == nil || v.(string) == "" {
+		return
+	}
+
+	if _, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(v.(string))); err != nil {
+		errors = append(errors,
+			fmt.Errorf("%s: unable to parse OpenSSH public key: %s", k, err))
+	} else if len(strings.TrimSpace(string(rest))) > 0 {
+		errors = append(errors,
+			fmt.Errorf("%s: expected a single OpenSSH public key", k))
+	}
+
+	return
+}